@@ -0,0 +1,60 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package qt
+
+import (
+	"fmt"
+	"time"
+)
+
+// This file holds checkers for calendar constraints on times, which are
+// mostly useful when testing scheduling logic. All the constraints are
+// checked in the location of the provided time: use time.Time.In to check
+// them in another location.
+
+// IsWeekday returns a Checker checking that the provided time falls on a
+// weekday, from Monday to Friday.
+func IsWeekday(got time.Time) Checker {
+	return &calendarChecker{
+		got: got,
+		check: func(t time.Time) error {
+			if d := t.Weekday(); d == time.Saturday || d == time.Sunday {
+				return fmt.Errorf("time falls on %s", d)
+			}
+			return nil
+		},
+	}
+}
+
+// HourBetween returns a Checker checking that the hour of the provided time
+// is within the given inclusive range. For instance, the following checks
+// that the time is between 9:00 and 17:59:
+//
+//	qt.Assert(t, qt.HourBetween(next, 9, 17))
+func HourBetween(got time.Time, minHour, maxHour int) Checker {
+	return &calendarChecker{
+		got: got,
+		check: func(t time.Time) error {
+			if minHour < 0 || maxHour > 23 || minHour > maxHour {
+				return BadCheckf("invalid hour range [%d,%d]", minHour, maxHour)
+			}
+			if h := t.Hour(); h < minHour || h > maxHour {
+				return fmt.Errorf("hour %d is outside [%d,%d]", h, minHour, maxHour)
+			}
+			return nil
+		},
+	}
+}
+
+type calendarChecker struct {
+	got   time.Time
+	check func(time.Time) error
+}
+
+func (c *calendarChecker) Check(note func(key string, value any)) error {
+	return c.check(c.got)
+}
+
+func (c *calendarChecker) Args() []Arg {
+	return []Arg{{Name: "got", Value: c.got}}
+}
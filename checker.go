@@ -3,12 +3,23 @@
 package qt
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"math"
 	"reflect"
 	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -29,6 +40,11 @@ type Checker interface {
 	// Args returns a slice of all the arguments passed
 	// to the checker. The first argument should always be
 	// the "got" value being checked.
+	//
+	// Args is only called when a failure is reported, so that
+	// successful checks do not pay the cost of building the
+	// arguments. Note that this includes the case where Check
+	// succeeds but the checker is negated with Not.
 	Args() []Arg
 }
 
@@ -62,7 +78,9 @@ func (c *equalsChecker[T]) Check(note func(key string, value any)) (err error) {
 		// A panic is raised when the provided values are interfaces containing
 		// non-comparable values.
 		if r := recover(); r != nil {
-			err = fmt.Errorf("%s", r)
+			note("got", c.got)
+			note("want", c.want)
+			err = BadCheckf("cannot compare values of type %s: they are not comparable; use qt.DeepEquals instead", uncomparableType(c.got, c.want))
 		}
 	}()
 
@@ -90,23 +108,65 @@ func (c *equalsChecker[T]) Check(note func(key string, value any)) (err error) {
 
 	// Show line diff when comparing different multi-line strings.
 	if c, ok := any(c).(*equalsChecker[string]); ok {
-		isMultiLine := func(s string) bool {
-			i := strings.Index(s, "\n")
-			return i != -1 && i < len(s)-1
-		}
-		if isMultiLine(c.got) || isMultiLine(c.want) {
-			diff := cmp.Diff(strings.SplitAfter(c.want, "\n"), strings.SplitAfter(c.got, "\n"))
-			note("line diff (-want +got)", Unquoted(diff))
-		}
+		noteLineDiff(c.got, c.want, note)
 	}
 
 	return errors.New("values are not equal")
 }
 
+// noteLineDiff adds a line diff note when any of the given different strings
+// spans multiple lines.
+func noteLineDiff(got, want string, note func(key string, value any)) {
+	isMultiLine := func(s string) bool {
+		i := strings.Index(s, "\n")
+		return i != -1 && i < len(s)-1
+	}
+	if isMultiLine(got) || isMultiLine(want) {
+		diff := cmp.Diff(strings.SplitAfter(want, "\n"), strings.SplitAfter(got, "\n"))
+		note("line diff (-want +got)", Unquoted(diff))
+	}
+}
+
 // DeepEquals returns a Checker checking equality of two values
-// using cmp.DeepEqual.
+// using cmp.DeepEqual. Options registered with RegisterCmpOption are used
+// for the comparison.
 func DeepEquals[T any](got, want T) Checker {
-	return CmpEquals(got, want)
+	return CmpEquals(got, want, registeredCmpOptions()...)
+}
+
+var (
+	cmpOptionsMu sync.Mutex
+	cmpOptions   []cmp.Option
+)
+
+// RegisterCmpOption registers an option used by default when comparing
+// values with DeepEquals, for instance a cmp.Comparer for a type whose values
+// must be compared in a custom way everywhere. It is usually called in
+// TestMain or in an init function.
+//
+// Registered options are also used by all the checkers documented as
+// comparing values as in DeepEquals, such as ContentEquals, DeepOneOf,
+// DeepEqualsIgnoring, MapSubset or SliceStartsWith. CmpEquals, and the
+// checkers accepting cmp options like JSONEquals, only use the options
+// explicitly passed to them.
+//
+// Registered options are applied in registration order, before any option
+// added by the checker itself, and only affect checkers created after the
+// registration.
+//
+// It is safe to call RegisterCmpOption concurrently.
+func RegisterCmpOption(opt cmp.Option) {
+	cmpOptionsMu.Lock()
+	defer cmpOptionsMu.Unlock()
+	cmpOptions = append(cmpOptions, opt)
+}
+
+// registeredCmpOptions returns a copy of the options registered with
+// RegisterCmpOption.
+func registeredCmpOptions() []cmp.Option {
+	cmpOptionsMu.Lock()
+	defer cmpOptionsMu.Unlock()
+	return append([]cmp.Option(nil), cmpOptions...)
 }
 
 // CmpEquals is like DeepEquals but allows custom compare options
@@ -136,216 +196,1876 @@ func (c *cmpEqualsChecker[T]) Check(note func(key string, value any)) (err error
 		}
 	}()
 	if diff := cmp.Diff(c.want, c.got, c.opts...); diff != "" {
+		// Functions are never deep equal unless they're both nil,
+		// so a difference in a function is most likely a mistake.
+		// The options are clipped so that appending the reporter never
+		// writes to the backing array of a slice provided by the caller.
+		var r diffReporter
+		cmp.Equal(c.want, c.got, append(c.opts[:len(c.opts):len(c.opts)], cmp.Reporter(&r))...)
+		if r.funcPath != nil {
+			if len(r.funcPath) == 1 {
+				return BadCheckf("cannot compare functions with DeepEquals; functions are only comparable to nil")
+			}
+			return BadCheckf("cannot compare functions with DeepEquals (found at %s); functions are only comparable to nil", r.funcPath.GoString())
+		}
 		// Only output values when the verbose flag is set.
 		note("error", Unquoted("values are not deep equal"))
+		if hasOption(c.opts, ReportFirstDifference) && r.diffPath != "" {
+			note("first difference at", Unquoted(r.diffPath))
+		}
+		if Format(c.got) == Format(c.want) {
+			note("note", Unquoted("values are formatted identically; they may differ by pointer identity or contain NaN"))
+		}
 		note("diff (-want +got)", Unquoted(diff))
 		note("got", SuppressedIfLong{c.got})
 		note("want", SuppressedIfLong{c.want})
+		if hasOption(c.opts, ReportGoSource) {
+			if src, err := goSource(c.got); err == nil {
+				note("got as Go source", Unquoted(src))
+			}
+		}
 		return ErrSilent
 	}
 	return nil
 }
 
-// ContentEquals is like DeepEquals but any slices in the compared values will
-// be sorted before being compared.
-func ContentEquals[T any](got, want T) Checker {
-	return CmpEquals(got, want, cmpopts.SortSlices(func(x, y any) bool {
-		// TODO frankban: implement a proper sort function.
-		return pretty.Sprint(x) < pretty.Sprint(y)
-	}))
+// relayDeepEqualsNotes returns a note function for checkers comparing values
+// as in DeepEquals, relaying their notes to notef so that they can be used to
+// implement other checkers. The error is replaced with errMsg, and the
+// compared values are described as gotName and wantName, including in the
+// diff.
+func relayDeepEqualsNotes(notef func(key string, value any), errMsg, gotName, wantName string) func(key string, value any) {
+	keys := map[string]string{
+		"diff (-want +got)": fmt.Sprintf("diff (-%s +%s)", wantName, gotName),
+		"got":               gotName,
+		"want":              wantName,
+	}
+	return func(key string, value any) {
+		if key == "error" {
+			notef("error", Unquoted(errMsg))
+			return
+		}
+		if k, ok := keys[key]; ok {
+			key = k
+		}
+		notef(key, value)
+	}
 }
 
-// Matches returns a Checker checking that the provided string matches the
-// provided regular expression pattern. If want is a string, the pattern will be
-// anchored; that is:
-//
-//	Matches(got, "foo")
-//
-// is equivalent to:
-//
-//	Matches(got, "^foo$")
+// DeepEqualsEquatingEmpty is like DeepEquals but nil and empty slices and
+// maps are considered equal, wherever they appear in the compared values.
+// It is a shorthand for CmpEquals(got, want, cmpopts.EquateEmpty()), with
+// the options registered with RegisterCmpOption.
+func DeepEqualsEquatingEmpty[T any](got, want T) Checker {
+	return CmpEquals(got, want, append(registeredCmpOptions(), cmpopts.EquateEmpty())...)
+}
+
+// DeepEqualsApprox is like DeepEquals but floating point numbers are
+// considered equal when they are within the given relative fraction or
+// absolute margin of each other, wherever they appear in the compared
+// values. It is a shorthand for
+// CmpEquals(got, want, cmpopts.EquateApprox(fraction, margin)), with the
+// options registered with RegisterCmpOption; see cmpopts.EquateApprox for
+// details.
 //
-// If want is of type *regexp.Regexp, it will be matched as is.
-func Matches[StringOrRegexp string | *regexp.Regexp](got string, want StringOrRegexp) Checker {
-	return &matchesChecker{
-		got:   got,
-		want:  want,
-		match: newMatcher(want),
+// Negative or NaN fraction and margin values result in a bad check.
+func DeepEqualsApprox[T any](got, want T, fraction, margin float64) Checker {
+	if fraction < 0 || margin < 0 || math.IsNaN(fraction) || math.IsNaN(margin) {
+		return failChecker{
+			err: BadCheckf("invalid approximation: fraction %v, margin %v", fraction, margin),
+		}
 	}
+	return CmpEquals(got, want, append(registeredCmpOptions(), cmpopts.EquateApprox(fraction, margin))...)
 }
 
-type matchesChecker struct {
-	got   string
-	want  any
-	match matcher
+// DeepEqualsIgnoring is like DeepEquals but the given struct fields are
+// ignored when comparing the values. Fields are specified by name, or by
+// dotted path for fields of embedded or nested structs, for instance
+// "Inner.Timestamp". Paths are resolved on the struct type found in T, after
+// dereferencing pointers and looking into the elements of slices, arrays and
+// maps, so that fields can be ignored in a []*Record value too.
+func DeepEqualsIgnoring[T any](got, want T, fields ...string) Checker {
+	return &deepEqualsIgnoringChecker[T]{
+		argPair: argPairOf(got, want),
+		fields:  fields,
+		opts:    registeredCmpOptions(),
+	}
 }
 
-func (c *matchesChecker) Check(note func(key string, value any)) error {
-	return c.match(c.got, "value does not match regexp", note)
+type deepEqualsIgnoringChecker[T any] struct {
+	argPair[T, T]
+	fields []string
+	opts   []cmp.Option
 }
 
-func (c *matchesChecker) Args() []Arg {
-	return []Arg{{Name: "got value", Value: c.got}, {Name: "regexp", Value: c.want}}
+func (c *deepEqualsIgnoringChecker[T]) Check(note func(key string, value any)) error {
+	opt, err := ignoreFields(typeOf[T](), c.fields)
+	if err != nil {
+		return BadCheckf("%s", err)
+	}
+	cc := &cmpEqualsChecker[T]{
+		argPair: c.argPair,
+		opts:    append(c.opts[:len(c.opts):len(c.opts)], opt),
+	}
+	return cc.Check(note)
 }
 
-// ErrorMatches returns a Checker checking that the provided value is an error
-// whose message matches the provided regular expression pattern
-// (see [Matches] for more details on how the pattern is matched).
-func ErrorMatches[StringOrRegexp string | *regexp.Regexp](got error, want StringOrRegexp) Checker {
-	return &errorMatchesChecker{
-		got:   got,
-		want:  want,
-		match: newMatcher(want),
+// ignoreFields returns a cmp.Option ignoring the given fields of the struct
+// type found in t.
+func ignoreFields(t reflect.Type, fields []string) (opt cmp.Option, err error) {
+	st := t
+	for st.Kind() != reflect.Struct {
+		switch st.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			st = st.Elem()
+		default:
+			return nil, fmt.Errorf("cannot ignore fields on type %s: no struct type found", t)
+		}
 	}
+	defer func() {
+		// cmpopts.IgnoreFields panics when a field cannot be found.
+		if r := recover(); r != nil {
+			err = fmt.Errorf("cannot ignore fields on type %s: %v", st, r)
+		}
+	}()
+	return cmpopts.IgnoreFields(reflect.Zero(st).Interface(), fields...), nil
 }
 
-type errorMatchesChecker struct {
-	got   error
-	want  any
-	match matcher
-}
+// CmpTimeEqual is a cmp.Option that compares time.Time values with
+// time.Time.Equal, so that times representing the same instant are
+// considered equal regardless of their monotonic clock readings or
+// locations. It is intended to be passed to CmpEquals, for instance:
+//
+//	qt.Assert(t, qt.CmpEquals(got, want, qt.CmpTimeEqual))
+var CmpTimeEqual = cmp.Comparer(func(x, y time.Time) bool {
+	return x.Equal(y)
+})
 
-func (c *errorMatchesChecker) Check(note func(key string, value any)) error {
-	if c.got == nil {
-		return errors.New("got nil error but want non-nil")
+// ReportFirstDifference is a cmp.Option that, when passed to CmpEquals,
+// makes failures include the path of the first difference between the two
+// values, for instance root.Items[3].Labels["env"]. This pinpoints the
+// divergence in large nested values. The option has no effect on the
+// comparison itself.
+//
+// To enable it for DeepEquals and ContentEquals, register it with
+// RegisterCmpOption:
+//
+//	qt.RegisterCmpOption(qt.ReportFirstDifference)
+var ReportFirstDifference cmp.Option = cmp.FilterPath(func(cmp.Path) bool {
+	return false
+}, cmp.Ignore())
+
+// ReportGoSource is a cmp.Option that, when passed to CmpEquals, makes
+// failures include the obtained value rendered as gofmt'd Go source, so
+// that it can be copied into the test to update the expected value. As with
+// ReportFirstDifference, the option has no effect on the comparison itself
+// and it can be registered with RegisterCmpOption to enable it for
+// DeepEquals.
+//
+// Type names in the rendered source are qualified with their package name,
+// and channels and functions are rendered as nil.
+var ReportGoSource cmp.Option = cmp.FilterPath(func(cmp.Path) bool {
+	return false
+}, cmp.Ignore())
+
+// FieldEquals returns a Checker checking that the value found at the given
+// path in got is equal to want, as in DeepEquals. This is useful when only a
+// single field of a large value is of interest.
+//
+// The path is made of dotted field names and of bracketed slice or array
+// indexes and map keys, for instance "User.Addresses[0].City" or
+// `Labels[env]`. Pointers and interfaces are dereferenced as required.
+//
+// An invalid path results in a bad check reporting where the resolution
+// failed.
+func FieldEquals(got any, path string, want any) Checker {
+	return &fieldEqualsChecker{
+		got:  got,
+		path: path,
+		want: want,
 	}
-	return c.match(c.got.Error(), "error does not match regexp", note)
 }
 
-func (c *errorMatchesChecker) Args() []Arg {
-	return []Arg{{Name: "got error", Value: c.got}, {Name: "regexp", Value: c.want}}
+type fieldEqualsChecker struct {
+	got  any
+	path string
+	want any
 }
 
-// PanicMatches returns a Checker checking that the provided function panics
-// with a message matching the provided regular expression pattern.
-// (see [Matches] for more details on how the pattern is matched).
-func PanicMatches[StringOrRegexp string | *regexp.Regexp](f func(), want StringOrRegexp) Checker {
-	return &panicMatchesChecker{
-		got:   f,
-		want:  want,
-		match: newMatcher(want),
+func (c *fieldEqualsChecker) Check(notef func(key string, value any)) error {
+	v, err := resolvePath(reflect.ValueOf(c.got), c.path)
+	if err != nil {
+		return err
+	}
+	var notes []note
+	err = DeepEquals(v, c.want).Check(func(key string, value any) {
+		notes = append(notes, note{key, value})
+	})
+	if err != ErrSilent {
+		return err
+	}
+	for i, n := range notes {
+		notef(n.key, n.value)
+		if i == 0 {
+			// Report the path right after the error.
+			notef("path", Unquoted(c.path))
+		}
 	}
+	return ErrSilent
 }
 
-type panicMatchesChecker struct {
-	got   func()
-	want  any
-	match matcher
+func (c *fieldEqualsChecker) Args() []Arg {
+	return []Arg{{
+		Name:  "got",
+		Value: SuppressedIfLong{c.got},
+	}, {
+		Name:  "path",
+		Value: Unquoted(c.path),
+	}, {
+		Name:  "want",
+		Value: c.want,
+	}}
 }
 
-func (c *panicMatchesChecker) Check(note func(key string, value any)) (err error) {
-	defer func() {
-		r := recover()
-		if r == nil {
-			err = errors.New("function did not panic")
-			return
+// resolvePath returns the value found at the given path in v. See FieldEquals
+// for a description of the path syntax.
+func resolvePath(v reflect.Value, path string) (any, error) {
+	if path == "" {
+		return nil, BadCheckf("empty path")
+	}
+	// at holds the part of the path already resolved.
+	at := "got"
+	for rest := path; rest != ""; {
+		for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return nil, fmt.Errorf("cannot resolve path: nil value at %s", at)
+			}
+			v = v.Elem()
 		}
-		msg := fmt.Sprint(r)
-		note("panic value", msg)
-		err = c.match(msg, "panic value does not match regexp", note)
-	}()
-	c.got()
-	return nil
+		if !v.IsValid() {
+			return nil, fmt.Errorf("cannot resolve path: nil value at %s", at)
+		}
+		if rest[0] == '[' {
+			end := strings.IndexByte(rest, ']')
+			if end == -1 {
+				return nil, BadCheckf("invalid path %q: missing closing bracket", path)
+			}
+			key := rest[1:end]
+			rest = rest[end+1:]
+			var err error
+			if v, err = resolveIndex(v, key, at); err != nil {
+				return nil, err
+			}
+			at += "[" + key + "]"
+			continue
+		}
+		if at != "got" {
+			if rest[0] != '.' {
+				return nil, BadCheckf("invalid path %q: unexpected %q after %s", path, rest[0], at)
+			}
+			rest = rest[1:]
+		}
+		end := strings.IndexAny(rest, ".[")
+		if end == -1 {
+			end = len(rest)
+		}
+		name := rest[:end]
+		rest = rest[end:]
+		if v.Kind() != reflect.Struct {
+			return nil, BadCheckf("cannot get field %q of %s at %s", name, v.Type(), at)
+		}
+		field, ok := v.Type().FieldByName(name)
+		if !ok {
+			return nil, BadCheckf("no field %q in %s at %s", name, v.Type(), at)
+		}
+		if !field.IsExported() {
+			return nil, BadCheckf("cannot get unexported field %q of %s at %s", name, v.Type(), at)
+		}
+		var err error
+		if v, err = v.FieldByIndexErr(field.Index); err != nil {
+			return nil, fmt.Errorf("cannot resolve path: nil embedded struct at %s", at)
+		}
+		at += "." + name
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+	return v.Interface(), nil
 }
 
-func (c *panicMatchesChecker) Args() []Arg {
-	return []Arg{{Name: "function", Value: c.got}, {Name: "regexp", Value: c.want}}
+// resolveIndex returns the element of the given slice, array or map v at
+// the given index or key. The at argument describes where v has been found.
+func resolveIndex(v reflect.Value, key, at string) (reflect.Value, error) {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.String:
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 {
+			return reflect.Value{}, BadCheckf("invalid index %q for %s at %s", key, v.Type(), at)
+		}
+		if i >= v.Len() {
+			return reflect.Value{}, fmt.Errorf("cannot resolve path: index %d out of range at %s (length %d)", i, at, v.Len())
+		}
+		return v.Index(i), nil
+	case reflect.Map:
+		k := reflect.New(v.Type().Key()).Elem()
+		if k.Kind() == reflect.String {
+			k.SetString(key)
+		} else if _, err := fmt.Sscan(key, k.Addr().Interface()); err != nil {
+			return reflect.Value{}, BadCheckf("invalid key %q for %s at %s", key, v.Type(), at)
+		}
+		elem := v.MapIndex(k)
+		if !elem.IsValid() {
+			return reflect.Value{}, fmt.Errorf("cannot resolve path: key %q not found at %s", key, at)
+		}
+		return elem, nil
+	}
+	return reflect.Value{}, BadCheckf("cannot index %s at %s", v.Type(), at)
 }
 
-// IsNil returns a Checker checking that the provided value is equal to nil.
+// NoZeroFields returns a Checker checking that none of the exported fields of
+// the provided struct, or pointer to struct, holds its zero value. This is
+// useful for catching forgotten assignments in constructors, builders or
+// decoders. On failure, the zero fields are listed by name.
 //
-// Note that an interface value containing a nil concrete
-// type is not considered to be nil.
-func IsNil[T any](got T) Checker {
-	return isNilChecker[T]{
-		got: got,
+// Fields that may legitimately be zero can be ignored by passing their
+// names. Only the top level fields are checked: use NoZeroFieldsDeep to also
+// check the fields of nested structs.
+func NoZeroFields(got any, ignore ...string) Checker {
+	return &noZeroFieldsChecker{
+		got:    got,
+		ignore: ignore,
 	}
 }
 
-type isNilChecker[T any] struct {
-	got T
-}
-
-func (c isNilChecker[T]) Check(note func(key string, value any)) error {
-	v := reflect.ValueOf(&c.got).Elem()
-	if !canBeNil(v.Kind()) {
-		return BadCheckf("type %v can never be nil", v.Type())
-	}
-	if v.IsNil() {
-		return nil
+// NoZeroFieldsDeep is like NoZeroFields but the fields of nested structs,
+// including structs pointed to by non-nil pointers, are checked recursively,
+// and are reported and ignored by dotted path, for instance "Meta.Owner".
+// Ignoring a nested struct ignores all its fields. A nil pointer to a struct
+// is reported as a zero field, even if some of its fields are ignored.
+// Structs without exported fields, such as time.Time, are checked as a
+// whole.
+func NoZeroFieldsDeep(got any, ignore ...string) Checker {
+	return &noZeroFieldsChecker{
+		got:    got,
+		ignore: ignore,
+		deep:   true,
 	}
-	return errors.New("got non-nil value")
 }
 
-func (c isNilChecker[T]) Args() []Arg {
-	return []Arg{{Name: "got", Value: c.got}}
+type noZeroFieldsChecker struct {
+	got    any
+	ignore []string
+	deep   bool
 }
 
-func (c isNilChecker[T]) negatedError() error {
+func (c *noZeroFieldsChecker) Check(note func(key string, value any)) error {
 	v := reflect.ValueOf(c.got)
-	if v.IsValid() {
-		return fmt.Errorf("got nil %s but want non-nil", v.Kind())
+	seen := make(map[uintptr]bool)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return BadCheckf("nil pointer provided")
+		}
+		seen[v.Pointer()] = true
+		v = v.Elem()
 	}
-	return errors.New("got <nil> but want non-nil")
-}
-
-// IsNotNil returns a Checker checking that the provided value is not nil.
-// IsNotNil(v) is the equivalent of qt.Not(qt.IsNil(v)).
-func IsNotNil[T any](got T) Checker {
-	return Not(IsNil(got))
+	if v.Kind() != reflect.Struct {
+		return BadCheckf("value of type %T is not a struct or a pointer to a struct", c.got)
+	}
+	ignore := make(map[string]bool)
+	for _, path := range c.ignore {
+		if !c.isField(v.Type(), path) {
+			return BadCheckf("ignored field %q not found in %s", path, v.Type())
+		}
+		ignore[path] = true
+	}
+	var zero []string
+	c.zeroFields(v, "", ignore, seen, &zero)
+	if len(zero) == 0 {
+		return nil
+	}
+	note("zero fields", Unquoted(strings.Join(zero, "\n")))
+	return errors.New("struct has zero-valued fields")
 }
 
-// HasLen returns a Checker checking that the provided value has the given
-// length. The value may be a slice, array, channel, map or string.
-func HasLen[T any](got T, n int) Checker {
-	return &hasLenChecker[T]{
-		got:     got,
-		wantLen: n,
+// isField reports whether the given path refers to an exported field of the
+// struct type t that is checked by this checker.
+func (c *noZeroFieldsChecker) isField(t reflect.Type, path string) bool {
+	names := strings.Split(path, ".")
+	if !c.deep && len(names) > 1 {
+		return false
+	}
+	for i, name := range names {
+		if t.Kind() == reflect.Pointer && i > 0 {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct || i > 0 && !hasExportedFields(t) {
+			return false
+		}
+		f, ok := t.FieldByName(name)
+		if !ok || !f.IsExported() || len(f.Index) != 1 {
+			return false
+		}
+		t = f.Type
 	}
+	return true
 }
 
-type hasLenChecker[T any] struct {
-	got     T
-	wantLen int
+// zeroFields appends to zero the paths of the zero exported fields of the
+// given struct value, skipping the ignored ones. The seen map holds the
+// pointers being followed on the current path, so that cycles are not
+// followed indefinitely.
+func (c *noZeroFieldsChecker) zeroFields(v reflect.Value, prefix string, ignore map[string]bool, seen map[uintptr]bool, zero *[]string) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		path := prefix + f.Name
+		if ignore[path] {
+			continue
+		}
+		fv := v.Field(i)
+		if c.deep {
+			elem, ptr := fv, uintptr(0)
+			if elem.Kind() == reflect.Pointer && !elem.IsNil() && !seen[elem.Pointer()] {
+				ptr = elem.Pointer()
+				elem = elem.Elem()
+			}
+			if elem.Kind() == reflect.Struct && hasExportedFields(elem.Type()) {
+				if ptr != 0 {
+					seen[ptr] = true
+				}
+				c.zeroFields(elem, path+".", ignore, seen, zero)
+				delete(seen, ptr)
+				continue
+			}
+		}
+		if fv.IsZero() {
+			*zero = append(*zero, path)
+		}
+	}
 }
 
-func (c *hasLenChecker[T]) Check(note func(key string, value any)) (err error) {
-	// TODO we're deliberately not allowing HasLen(interfaceValue) here.
-	// Perhaps we should?
-	v := reflect.ValueOf(&c.got).Elem()
-	switch v.Kind() {
-	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
-	case reflect.Pointer:
-		// Allow a pointer to array.
-		if reflect.Indirect(reflect.ValueOf(c.got)).Kind() == reflect.Array {
-			break
+// hasExportedFields reports whether the given struct type has any exported
+// field.
+func hasExportedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
 		}
-
-		// Error on all other pointers.
-		note("got", c.got)
-		return BadCheckf("first argument of type %v has no length", v.Type())
-	default:
-		note("got", c.got)
-		return BadCheckf("first argument of type %v has no length", v.Type())
 	}
-	length := v.Len()
-	note("len(got)", length)
-	if length != c.wantLen {
-		return fmt.Errorf("unexpected length")
-	}
-	return nil
+	return false
 }
 
-func (c *hasLenChecker[T]) Args() []Arg {
-	return []Arg{{Name: "got", Value: c.got}, {Name: "want length", Value: c.wantLen}}
+func (c *noZeroFieldsChecker) Args() []Arg {
+	return []Arg{{Name: "got", Value: c.got}}
 }
 
-// Implements returns a Checker checking that the provided value implements the
-// interface specified by the type parameter.
-func Implements[I any](got any) Checker {
-	return &implementsChecker{
+// ContentEquals is like DeepEquals but any slices in the compared values will
+// be sorted before being compared.
+func ContentEquals[T any](got, want T) Checker {
+	return CmpEquals(got, want, append(registeredCmpOptions(), cmpopts.SortSlices(func(x, y any) bool {
+		// TODO frankban: implement a proper sort function.
+		return pretty.Sprint(x) < pretty.Sprint(y)
+	}))...)
+}
+
+// OneOf returns a Checker checking that got is equal to any of the provided
+// values.
+func OneOf[T comparable](got T, want ...T) Checker {
+	return &oneOfChecker[T]{
 		got:  got,
-		want: typeOf[I](),
+		want: want,
+		equal: func(x, y T) bool {
+			return x == y
+		},
+	}
+}
+
+// DeepOneOf is like OneOf but values are compared with cmp.Equal, as in
+// DeepEquals, so that it can be used with types that are not comparable.
+// On failure, a diff between got and each of the provided values is
+// reported, so that it is easier to find out the closest one.
+func DeepOneOf[T any](got T, want ...T) Checker {
+	opts := registeredCmpOptions()
+	return &oneOfChecker[T]{
+		got:  got,
+		want: want,
+		equal: func(x, y T) bool {
+			return cmp.Equal(x, y, opts...)
+		},
+		diff: func(x, y T) string {
+			return cmp.Diff(y, x, opts...)
+		},
+	}
+}
+
+type oneOfChecker[T any] struct {
+	got   T
+	want  []T
+	equal func(x, y T) bool
+	// diff, if not nil, is used to report the differences between got and
+	// each value on failure.
+	diff func(x, y T) string
+}
+
+func (c *oneOfChecker[T]) Check(note func(key string, value any)) (err error) {
+	if len(c.want) == 0 {
+		return BadCheckf("no values provided")
+	}
+	defer func() {
+		// A panic is raised in some cases, for instance when trying to compare
+		// structs with unexported fields.
+		if r := recover(); r != nil {
+			err = BadCheckf("%s", r)
+		}
+	}()
+	for _, want := range c.want {
+		if c.equal(c.got, want) {
+			return nil
+		}
+	}
+	if c.diff != nil {
+		for i, want := range c.want {
+			note(fmt.Sprintf("diff with value %d (-want +got)", i), Unquoted(c.diff(c.got, want)))
+		}
+	}
+	return errors.New("value is not one of the allowed values")
+}
+
+func (c *oneOfChecker[T]) Args() []Arg {
+	return []Arg{{Name: "got", Value: c.got}, {Name: "want one of", Value: c.want}}
+}
+
+// SetEquals returns a Checker checking that the two slices hold the same
+// distinct elements, regardless of their order and of how many times each
+// element appears. Unlike ContentEquals, SetEquals([]int{1, 1, 2}, []int{2, 1})
+// succeeds.
+//
+// On failure, the elements present in only one of the slices are reported.
+func SetEquals[T comparable](got, want []T) Checker {
+	return &setEqualsChecker[T]{
+		argPair: argPairOf(got, want),
+	}
+}
+
+type setEqualsChecker[T comparable] struct {
+	argPair[[]T, []T]
+}
+
+func (c *setEqualsChecker[T]) Check(note func(key string, value any)) error {
+	return checkSetEquals(c.got, c.want, "elements", note)
+}
+
+// checkSetEquals checks that got and want hold the same distinct elements,
+// which are described with the given name in the notes added on failure.
+func checkSetEquals[T comparable](got, want []T, name string, note func(key string, value any)) error {
+	extra, missing := setDiff(got, want), setDiff(want, got)
+	if len(extra) == 0 && len(missing) == 0 {
+		return nil
+	}
+	if len(extra) > 0 {
+		note("unexpected "+name, extra)
+	}
+	if len(missing) > 0 {
+		note("missing "+name, missing)
+	}
+	return errors.New("sets are not equal")
+}
+
+// setDiff returns the distinct elements of a that are not in b,
+// in the order in which they first appear in a.
+func setDiff[T comparable](a, b []T) []T {
+	seen := make(map[T]bool, len(b))
+	for _, v := range b {
+		seen[v] = true
+	}
+	var diff []T
+	for _, v := range a {
+		if !seen[v] {
+			seen[v] = true
+			diff = append(diff, v)
+		}
+	}
+	return diff
+}
+
+// IsPermutationOf returns a Checker checking that got is a reordering of
+// want, that is, that both slices hold the same elements the same number of
+// times. On failure, the elements whose counts differ are reported along with
+// their count in each slice.
+func IsPermutationOf[T comparable](got, want []T) Checker {
+	return &isPermutationOfChecker[T]{
+		argPair: argPairOf(got, want),
+	}
+}
+
+// IsPermutation returns a Checker checking that the provided slice is a
+// permutation of the indices 0 to len(got)-1. This is useful for testing
+// sorting and shuffling algorithms working on indices.
+func IsPermutation(got []int) Checker {
+	want := make([]int, len(got))
+	for i := range want {
+		want[i] = i
+	}
+	return IsPermutationOf(got, want)
+}
+
+type isPermutationOfChecker[T comparable] struct {
+	argPair[[]T, []T]
+}
+
+func (c *isPermutationOfChecker[T]) Check(note func(key string, value any)) error {
+	gotCounts, wantCounts := make(map[T]int), make(map[T]int)
+	for _, v := range c.got {
+		gotCounts[v]++
+	}
+	for _, v := range c.want {
+		wantCounts[v]++
+	}
+	// Report differences in the order elements first appear in want, then
+	// in got.
+	var diffs []string
+	reported := make(map[T]bool)
+	for _, elems := range [][]T{c.want, c.got} {
+		for _, v := range elems {
+			if reported[v] || gotCounts[v] == wantCounts[v] {
+				continue
+			}
+			reported[v] = true
+			diffs = append(diffs, fmt.Sprintf("%s: got %d, want %d", Format(v), gotCounts[v], wantCounts[v]))
+		}
+	}
+	if len(diffs) == 0 {
+		return nil
+	}
+	note("count differences", Unquoted(strings.Join(diffs, "\n")))
+	return errors.New("slices are not permutations of each other")
+}
+
+// MapKeys returns a Checker checking that the keys of the provided map are
+// exactly the given keys, in any order. On failure, the missing and unexpected
+// keys are reported.
+func MapKeys[K comparable, V any](m map[K]V, want []K) Checker {
+	return &mapKeysChecker[K, V]{
+		got:  m,
+		want: want,
+	}
+}
+
+type mapKeysChecker[K comparable, V any] struct {
+	got  map[K]V
+	want []K
+}
+
+func (c *mapKeysChecker[K, V]) Check(note func(key string, value any)) error {
+	keys := make([]K, 0, len(c.got))
+	for k := range c.got {
+		keys = append(keys, k)
+	}
+	// Sort the keys so that unexpected keys are reported consistently.
+	sort.Slice(keys, func(i, j int) bool {
+		return Format(keys[i]) < Format(keys[j])
+	})
+	return checkSetEquals(keys, c.want, "keys", note)
+}
+
+func (c *mapKeysChecker[K, V]) Args() []Arg {
+	return []Arg{{Name: "got", Value: c.got}, {Name: "want keys", Value: c.want}}
+}
+
+// MapSubset returns a Checker checking that every key in got is also present
+// in want, with a value that is deep equal to the one in got, as in
+// DeepEquals. On failure, the first key, in formatted order, that is missing
+// from want or whose value differs is reported.
+func MapSubset[K comparable, V any](got, want map[K]V) Checker {
+	return &mapSubsetChecker[K, V]{
+		argPair: argPairOf(got, want),
+		opts:    registeredCmpOptions(),
+	}
+}
+
+type mapSubsetChecker[K comparable, V any] struct {
+	argPair[map[K]V, map[K]V]
+	opts []cmp.Option
+}
+
+func (c *mapSubsetChecker[K, V]) Check(note func(key string, value any)) (err error) {
+	defer func() {
+		// A panic is raised in some cases, for instance when trying to compare
+		// structs with unexported fields.
+		if r := recover(); r != nil {
+			err = BadCheckf("%s", r)
+		}
+	}()
+	keys := make([]K, 0, len(c.got))
+	for k := range c.got {
+		keys = append(keys, k)
+	}
+	// Sort the keys so that failures are reported consistently.
+	sort.Slice(keys, func(i, j int) bool {
+		return Format(keys[i]) < Format(keys[j])
+	})
+	for _, k := range keys {
+		want, ok := c.want[k]
+		if !ok {
+			note("key", k)
+			return errors.New("key not found in want")
+		}
+		if diff := cmp.Diff(want, c.got[k], c.opts...); diff != "" {
+			note("key", k)
+			note("diff (-want +got)", Unquoted(diff))
+			return errors.New("values differ for key")
+		}
+	}
+	return nil
+}
+
+// MapEqualsIgnoring is like DeepEquals for maps, but the given keys are
+// removed from both maps before comparing them. This is useful when maps
+// hold entries that cannot be predicted, like timestamps or request IDs. On
+// failure, a diff of the filtered maps is reported.
+func MapEqualsIgnoring[K comparable, V any](got, want map[K]V, ignore ...K) Checker {
+	return &mapEqualsIgnoringChecker[K, V]{
+		argPair: argPairOf(got, want),
+		ignore:  ignore,
+	}
+}
+
+type mapEqualsIgnoringChecker[K comparable, V any] struct {
+	argPair[map[K]V, map[K]V]
+	ignore []K
+}
+
+func (c *mapEqualsIgnoringChecker[K, V]) Check(note func(key string, value any)) error {
+	return DeepEquals(c.filter(c.got), c.filter(c.want)).Check(note)
+}
+
+// filter returns a copy of m without the ignored keys.
+func (c *mapEqualsIgnoringChecker[K, V]) filter(m map[K]V) map[K]V {
+	if m == nil {
+		return nil
+	}
+	filtered := make(map[K]V, len(m))
+	for k, v := range m {
+		filtered[k] = v
+	}
+	for _, k := range c.ignore {
+		delete(filtered, k)
+	}
+	return filtered
+}
+
+func (c *mapEqualsIgnoringChecker[K, V]) Args() []Arg {
+	return append(c.argPair.Args(), Arg{
+		Name:  "ignored keys",
+		Value: c.ignore,
+	})
+}
+
+// NoDuplicates returns a Checker checking that no element appears more than
+// once in the provided slice. On failure, the first duplicated element is
+// reported along with all the indices at which it appears.
+func NoDuplicates[T comparable](got []T) Checker {
+	return &noDuplicatesChecker[T]{
+		got: got,
+	}
+}
+
+type noDuplicatesChecker[T comparable] struct {
+	got []T
+}
+
+func (c *noDuplicatesChecker[T]) Check(note func(key string, value any)) error {
+	seen := make(map[T]int, len(c.got))
+	for i, v := range c.got {
+		first, ok := seen[v]
+		if !ok {
+			seen[v] = i
+			continue
+		}
+		indices := []int{first, i}
+		for j := i + 1; j < len(c.got); j++ {
+			if c.got[j] == v {
+				indices = append(indices, j)
+			}
+		}
+		note("duplicate element", v)
+		note("indices", indices)
+		return errors.New("duplicate element found")
+	}
+	return nil
+}
+
+func (c *noDuplicatesChecker[T]) Args() []Arg {
+	return []Arg{{Name: "got", Value: c.got}}
+}
+
+// UniqueBy returns a Checker checking that no two elements of the provided
+// slice have the same key, as returned by keyFn. This is useful when elements
+// are not comparable, or when uniqueness only applies to part of them, for
+// instance an ID field. On failure, the first duplicated key is reported along
+// with the two conflicting elements.
+func UniqueBy[T any, K comparable](got []T, keyFn func(T) K) Checker {
+	return &uniqueByChecker[T, K]{
+		got:   got,
+		keyFn: keyFn,
+	}
+}
+
+type uniqueByChecker[T any, K comparable] struct {
+	got   []T
+	keyFn func(T) K
+}
+
+func (c *uniqueByChecker[T, K]) Check(note func(key string, value any)) error {
+	if c.keyFn == nil {
+		return BadCheckf("nil key function provided")
+	}
+	seen := make(map[K]int, len(c.got))
+	for i, v := range c.got {
+		key := c.keyFn(v)
+		first, ok := seen[key]
+		if !ok {
+			seen[key] = i
+			continue
+		}
+		note("duplicate key", key)
+		note(fmt.Sprintf("element %d", first), c.got[first])
+		note(fmt.Sprintf("element %d", i), v)
+		return errors.New("duplicate key found")
+	}
+	return nil
+}
+
+func (c *uniqueByChecker[T, K]) Args() []Arg {
+	return []Arg{{Name: "got", Value: c.got}, {Name: "key function", Value: c.keyFn}}
+}
+
+// SliceCount returns a Checker checking that elem appears exactly want times
+// in the provided slice. Elements are compared with ==.
+func SliceCount[T comparable](got []T, elem T, want int) Checker {
+	return &sliceCountChecker[T]{
+		got:  got,
+		elem: elem,
+		want: want,
+	}
+}
+
+type sliceCountChecker[T comparable] struct {
+	got  []T
+	elem T
+	want int
+}
+
+func (c *sliceCountChecker[T]) Check(note func(key string, value any)) error {
+	if c.want < 0 {
+		return BadCheckf("negative count %d", c.want)
+	}
+	n := 0
+	for _, v := range c.got {
+		if v == c.elem {
+			n++
+		}
+	}
+	if n == c.want {
+		return nil
+	}
+	note("count", n)
+	return errors.New("unexpected number of occurrences")
+}
+
+func (c *sliceCountChecker[T]) Args() []Arg {
+	return []Arg{{Name: "got", Value: c.got}, {Name: "element", Value: c.elem}, {Name: "want count", Value: c.want}}
+}
+
+// MappedEquals returns a Checker checking that the result of applying fn to
+// each element of the provided slice is deep equal to want. This is useful
+// for checking a projection of a slice, for instance the names of a slice of
+// structs. Options registered with RegisterCmpOption are used for the
+// comparison. On failure, the first index at which the mapped values diverge
+// is reported along with a diff.
+func MappedEquals[T, U any](got []T, fn func(T) U, want []U) Checker {
+	return &mappedEqualsChecker[T, U]{
+		got:  got,
+		fn:   fn,
+		want: want,
+	}
+}
+
+type mappedEqualsChecker[T, U any] struct {
+	got  []T
+	fn   func(T) U
+	want []U
+}
+
+func (c *mappedEqualsChecker[T, U]) Check(notef func(key string, value any)) error {
+	if c.fn == nil {
+		return BadCheckf("nil mapping function provided")
+	}
+	mapped := make([]U, len(c.got))
+	for i, v := range c.got {
+		mapped[i] = c.fn(v)
+	}
+	opts := registeredCmpOptions()
+	relay := relayDeepEqualsNotes(notef, "mapped values are not deep equal", "mapped", "want")
+	return CmpEquals(mapped, c.want, opts...).Check(func(key string, value any) {
+		relay(key, value)
+		if key != "error" {
+			return
+		}
+		index := 0
+		for index < len(mapped) && index < len(c.want) && cmp.Equal(mapped[index], c.want[index], opts...) {
+			index++
+		}
+		notef("first mismatch at index", index)
+	})
+}
+
+func (c *mappedEqualsChecker[T, U]) Args() []Arg {
+	return []Arg{{Name: "got", Value: c.got}, {Name: "mapping function", Value: c.fn}, {Name: "want", Value: c.want}}
+}
+
+// SliceStartsWith returns a Checker checking that the provided slice starts
+// with the given prefix. Elements are compared with cmp.Diff, as in
+// DeepEquals.
+func SliceStartsWith[T any](got, prefix []T) Checker {
+	return &sliceAffixChecker[T]{
+		got:   got,
+		affix: prefix,
+		name:  "prefix",
+		opts:  registeredCmpOptions(),
+		verb:  "start",
+		window: func(got []T, n int) []T {
+			return got[:n]
+		},
+	}
+}
+
+// SliceEndsWith returns a Checker checking that the provided slice ends with
+// the given suffix. Elements are compared with cmp.Diff, as in DeepEquals.
+func SliceEndsWith[T any](got, suffix []T) Checker {
+	return &sliceAffixChecker[T]{
+		got:   got,
+		affix: suffix,
+		name:  "suffix",
+		opts:  registeredCmpOptions(),
+		verb:  "end",
+		window: func(got []T, n int) []T {
+			return got[len(got)-n:]
+		},
+	}
+}
+
+type sliceAffixChecker[T any] struct {
+	got, affix []T
+	name, verb string
+	window     func(got []T, n int) []T
+	opts       []cmp.Option
+}
+
+func (c *sliceAffixChecker[T]) Check(note func(key string, value any)) (err error) {
+	if len(c.got) < len(c.affix) {
+		note("len(got)", len(c.got))
+		note("len("+c.name+")", len(c.affix))
+		return fmt.Errorf("slice is shorter than %s", c.name)
+	}
+	if len(c.affix) == 0 {
+		return nil
+	}
+	defer func() {
+		// A panic is raised in some cases, for instance when trying to compare
+		// structs with unexported fields.
+		if r := recover(); r != nil {
+			err = BadCheckf("%s", r)
+		}
+	}()
+	if diff := cmp.Diff(c.affix, c.window(c.got, len(c.affix)), c.opts...); diff != "" {
+		note("diff (-"+c.name+" +got)", Unquoted(diff))
+		return fmt.Errorf("slice does not %s with %s", c.verb, c.name)
+	}
+	return nil
+}
+
+func (c *sliceAffixChecker[T]) Args() []Arg {
+	return []Arg{{Name: "got", Value: c.got}, {Name: c.name, Value: c.affix}}
+}
+
+// IsSubsequence returns a Checker checking that the elements of got appear in
+// want in the same relative order, not necessarily contiguously. On failure,
+// the first element of got that cannot be matched in order is reported.
+func IsSubsequence[T comparable](got, want []T) Checker {
+	return &isSubsequenceChecker[T]{argPairOf(got, want)}
+}
+
+type isSubsequenceChecker[T comparable] struct {
+	argPair[[]T, []T]
+}
+
+func (c *isSubsequenceChecker[T]) Check(note func(key string, value any)) error {
+	j := 0
+	for i, v := range c.got {
+		for j < len(c.want) && c.want[j] != v {
+			j++
+		}
+		if j == len(c.want) {
+			note("unmatched element", v)
+			note("index", i)
+			return errors.New("slice is not a subsequence")
+		}
+		j++
+	}
+	return nil
+}
+
+// IsStrictlyIncreasing returns a Checker checking that each element of the
+// provided slice is strictly greater than the one before it. Equal adjacent
+// elements cause the check to fail.
+func IsStrictlyIncreasing[T ordered](got []T) Checker {
+	return &strictOrderChecker[T]{
+		got:   got,
+		order: "increasing",
+		ok:    func(a, b T) bool { return a < b },
+	}
+}
+
+// IsStrictlyDecreasing returns a Checker checking that each element of the
+// provided slice is strictly less than the one before it. Equal adjacent
+// elements cause the check to fail.
+func IsStrictlyDecreasing[T ordered](got []T) Checker {
+	return &strictOrderChecker[T]{
+		got:   got,
+		order: "decreasing",
+		ok:    func(a, b T) bool { return a > b },
+	}
+}
+
+type strictOrderChecker[T ordered] struct {
+	got   []T
+	order string
+	ok    func(a, b T) bool
+}
+
+func (c *strictOrderChecker[T]) Check(note func(key string, value any)) error {
+	for i := 1; i < len(c.got); i++ {
+		if c.ok(c.got[i-1], c.got[i]) {
+			continue
+		}
+		note("indices", []int{i - 1, i})
+		note("elements", []T{c.got[i-1], c.got[i]})
+		return fmt.Errorf("slice is not strictly %s", c.order)
+	}
+	return nil
+}
+
+func (c *strictOrderChecker[T]) Args() []Arg {
+	return []Arg{{Name: "got", Value: c.got}}
+}
+
+// LessFunc returns a Checker checking that got is less than want according
+// to the provided three-way comparison function, which must return a negative
+// number when a < b, zero when a == b and a positive number when a > b. This
+// is useful for types ordered by a Compare method, for instance:
+//
+//	qt.Assert(t, qt.LessFunc(start, end, time.Time.Compare))
+func LessFunc[T any](got, want T, compare func(a, b T) int) Checker {
+	return &compareFuncChecker[T]{
+		argPair:  argPairOf(got, want),
+		compare:  compare,
+		relation: "less than",
+		ok:       func(n int) bool { return n < 0 },
+	}
+}
+
+// GreaterFunc returns a Checker checking that got is greater than want
+// according to the provided three-way comparison function. See LessFunc.
+func GreaterFunc[T any](got, want T, compare func(a, b T) int) Checker {
+	return &compareFuncChecker[T]{
+		argPair:  argPairOf(got, want),
+		compare:  compare,
+		relation: "greater than",
+		ok:       func(n int) bool { return n > 0 },
+	}
+}
+
+// EqualFunc returns a Checker checking that got is equal to want according
+// to the provided three-way comparison function. See LessFunc.
+func EqualFunc[T any](got, want T, compare func(a, b T) int) Checker {
+	return &compareFuncChecker[T]{
+		argPair:  argPairOf(got, want),
+		compare:  compare,
+		relation: "equal to",
+		ok:       func(n int) bool { return n == 0 },
+	}
+}
+
+type compareFuncChecker[T any] struct {
+	argPair[T, T]
+	compare  func(a, b T) int
+	relation string
+	ok       func(n int) bool
+}
+
+func (c *compareFuncChecker[T]) Check(note func(key string, value any)) error {
+	if c.compare == nil {
+		return BadCheckf("nil compare function provided")
+	}
+	n := c.compare(c.got, c.want)
+	if c.ok(n) {
+		return nil
+	}
+	note("compare result", n)
+	return fmt.Errorf("got is not %s want", c.relation)
+}
+
+// Matches returns a Checker checking that the provided string matches the
+// provided regular expression pattern. If want is a string, the pattern will be
+// anchored; that is:
+//
+//	Matches(got, "foo")
+//
+// is equivalent to:
+//
+//	Matches(got, "^foo$")
+//
+// If want is of type *regexp.Regexp, it will be matched as is.
+//
+// Anchoring can be disabled globally by setting AnchorPatterns to false.
+func Matches[StringOrRegexp string | *regexp.Regexp](got string, want StringOrRegexp) Checker {
+	return &matchesChecker{
+		got:   got,
+		want:  want,
+		match: newMatcher(want, true),
+	}
+}
+
+// MatchesPart is like Matches except that a pattern provided as a string
+// is not anchored, so it succeeds if any part of the provided string
+// matches the pattern. That is:
+//
+//	MatchesPart(got, "foo")
+//
+// is equivalent to:
+//
+//	Matches(got, ".*foo.*")
+//
+// If want is of type *regexp.Regexp, it will be matched as is.
+func MatchesPart[StringOrRegexp string | *regexp.Regexp](got string, want StringOrRegexp) Checker {
+	return &matchesChecker{
+		got:   got,
+		want:  want,
+		match: newMatcher(want, false),
+	}
+}
+
+type matchesChecker struct {
+	got   string
+	want  any
+	match matcher
+}
+
+func (c *matchesChecker) Check(note func(key string, value any)) error {
+	return c.match(c.got, "value does not match regexp", note)
+}
+
+func (c *matchesChecker) Args() []Arg {
+	return []Arg{{Name: "got value", Value: c.got}, {Name: "regexp", Value: c.want}}
+}
+
+// MatchesNamed returns a Checker checking that the provided string matches
+// the provided regular expression, and that the values of the named capture
+// groups of the leftmost match are equal to want. Unnamed groups are ignored.
+// Unlike Matches, the pattern is not anchored.
+//
+// For instance:
+//
+//	re := regexp.MustCompile(`(?P<level>[A-Z]+) (?P<msg>.*)`)
+//	qt.Assert(t, qt.MatchesNamed("ERROR disk full", re, map[string]string{
+//		"level": "ERROR",
+//		"msg":   "disk full",
+//	}))
+func MatchesNamed(got string, pattern *regexp.Regexp, want map[string]string) Checker {
+	return &matchesNamedChecker{
+		got:     got,
+		pattern: pattern,
+		want:    want,
+	}
+}
+
+type matchesNamedChecker struct {
+	got     string
+	pattern *regexp.Regexp
+	want    map[string]string
+}
+
+func (c *matchesNamedChecker) Check(note func(key string, value any)) error {
+	if c.pattern == nil {
+		return BadCheckf("nil regexp provided")
+	}
+	match := c.pattern.FindStringSubmatch(c.got)
+	if match == nil {
+		return errors.New("value does not match regexp")
+	}
+	groups := make(map[string]string)
+	for i, name := range c.pattern.SubexpNames() {
+		if name != "" {
+			groups[name] = match[i]
+		}
+	}
+	if diff := cmp.Diff(c.want, groups, cmpopts.EquateEmpty()); diff != "" {
+		note("named groups", groups)
+		note("diff (-want +got)", Unquoted(diff))
+		return errors.New("named groups do not match")
+	}
+	return nil
+}
+
+func (c *matchesNamedChecker) Args() []Arg {
+	return []Arg{{Name: "got value", Value: c.got}, {Name: "regexp", Value: c.pattern}, {Name: "want groups", Value: c.want}}
+}
+
+// RegexpCompiles returns a Checker checking that the provided regular
+// expression pattern compiles, as with regexp.Compile. On failure, the
+// compile error is reported. This is useful when testing code generating
+// patterns.
+func RegexpCompiles(pattern string) Checker {
+	return &regexpCompilesChecker{
+		pattern: pattern,
+	}
+}
+
+// RegexpInvalid returns a Checker checking that the provided regular
+// expression pattern does not compile, as with regexp.Compile.
+func RegexpInvalid(pattern string) Checker {
+	return &regexpCompilesChecker{
+		pattern: pattern,
+		invalid: true,
+	}
+}
+
+type regexpCompilesChecker struct {
+	pattern string
+	invalid bool
+}
+
+func (c *regexpCompilesChecker) Check(note func(key string, value any)) error {
+	_, err := regexp.Compile(c.pattern)
+	switch {
+	case err != nil && !c.invalid:
+		return fmt.Errorf("cannot compile regexp: %s", err)
+	case err == nil && c.invalid:
+		return errors.New("regexp compiles successfully")
+	case err != nil:
+		note("compile error", Unquoted(err.Error()))
+	}
+	return nil
+}
+
+func (c *regexpCompilesChecker) Args() []Arg {
+	return []Arg{{Name: "regexp", Value: c.pattern}}
+}
+
+// StringEquals returns a Checker checking that the String method of the
+// provided value returns want. This is useful for types whose textual
+// representation is part of their contract, such as enums or identifiers.
+func StringEquals(got fmt.Stringer, want string) Checker {
+	return &stringerChecker{
+		got:     got,
+		want:    want,
+		wantKey: "want",
+		check: func(s string, note func(key string, value any)) error {
+			if s == want {
+				return nil
+			}
+			noteLineDiff(s, want, note)
+			return errors.New("String output is not equal")
+		},
+	}
+}
+
+// StringMatches returns a Checker checking that the String method of the
+// provided value returns a string matching the provided regular expression
+// pattern (see Matches for more details on how the pattern is matched).
+func StringMatches[StringOrRegexp string | *regexp.Regexp](got fmt.Stringer, pattern StringOrRegexp) Checker {
+	match := newMatcher(pattern, true)
+	return &stringerChecker{
+		got:     got,
+		want:    pattern,
+		wantKey: "regexp",
+		check: func(s string, note func(key string, value any)) error {
+			return match(s, "String output does not match regexp", note)
+		},
+	}
+}
+
+type stringerChecker struct {
+	got     fmt.Stringer
+	want    any
+	wantKey string
+	check   func(s string, note func(key string, value any)) error
+}
+
+func (c *stringerChecker) Check(note func(key string, value any)) (err error) {
+	if c.got == nil {
+		return BadCheckf("nil Stringer provided")
+	}
+	defer func() {
+		// A panic is raised for instance when String is called on a typed
+		// nil pointer whose method does not handle nil receivers.
+		if r := recover(); r != nil {
+			err = BadCheckf("String method panicked: %v", r)
+		}
+	}()
+	s := c.got.String()
+	err = c.check(s, note)
+	if !IsBadCheck(err) {
+		// The string is also reported on success in case the checker is
+		// negated.
+		note("got string", s)
+	}
+	return err
+}
+
+func (c *stringerChecker) Args() []Arg {
+	return []Arg{{Name: c.wantKey, Value: c.want}}
+}
+
+// ErrorMatches returns a Checker checking that the provided value is an error
+// whose message matches the provided regular expression pattern
+// (see [Matches] for more details on how the pattern is matched).
+func ErrorMatches[StringOrRegexp string | *regexp.Regexp](got error, want StringOrRegexp) Checker {
+	return &errorMatchesChecker{
+		got:   got,
+		want:  want,
+		match: newMatcher(want, true),
+	}
+}
+
+type errorMatchesChecker struct {
+	got   error
+	want  any
+	match matcher
+}
+
+func (c *errorMatchesChecker) Check(note func(key string, value any)) error {
+	if c.got == nil {
+		return errors.New("got nil error but want non-nil")
+	}
+	return c.match(c.got.Error(), "error does not match regexp", note)
+}
+
+func (c *errorMatchesChecker) Args() []Arg {
+	return []Arg{{Name: "got error", Value: c.got}, {Name: "regexp", Value: c.want}}
+}
+
+// ErrorHasMessage returns a Checker checking that the provided value is an
+// error whose message is exactly the provided string. Unlike ErrorMatches, the
+// message is not interpreted as a regular expression, so it does not need to
+// be escaped.
+func ErrorHasMessage(got error, want string) Checker {
+	return &errorHasMessageChecker{
+		got:  got,
+		want: want,
+	}
+}
+
+type errorHasMessageChecker struct {
+	got  error
+	want string
+}
+
+func (c *errorHasMessageChecker) Check(note func(key string, value any)) error {
+	if c.got == nil {
+		return errors.New("got nil error but want non-nil")
+	}
+	if msg := c.got.Error(); msg != c.want {
+		noteLineDiff(msg, c.want, note)
+		return errors.New("error message is not equal")
+	}
+	return nil
+}
+
+func (c *errorHasMessageChecker) Args() []Arg {
+	return []Arg{{Name: "got error", Value: c.got}, {Name: "want message", Value: c.want}}
+}
+
+// ErrorDoesNotContain returns a Checker checking that the provided value is
+// an error whose message does not contain the given substring. This is
+// useful for checking that errors do not leak information, such as file
+// paths or secrets. Unlike Not(ErrorMatches(...)), a nil error is reported as
+// a failure, and an empty substring results in a bad check.
+//
+// Note that on failure both the error and the substring are included in the
+// report, which therefore contains the information the check is meant to
+// protect. Use Redact to mask it:
+//
+//	qt.Assert(t, qt.ErrorDoesNotContain(err, secret), qt.Redact(secret))
+func ErrorDoesNotContain(got error, substr string) Checker {
+	return &errorDoesNotContainChecker{
+		got:    got,
+		substr: substr,
+	}
+}
+
+type errorDoesNotContainChecker struct {
+	got    error
+	substr string
+}
+
+func (c *errorDoesNotContainChecker) Check(note func(key string, value any)) error {
+	if c.substr == "" {
+		return BadCheckf("empty substring")
+	}
+	if c.got == nil {
+		return errors.New("got nil error but want non-nil")
+	}
+	if i := strings.Index(c.got.Error(), c.substr); i != -1 {
+		note("found at offset", i)
+		return errors.New("error message contains substring")
+	}
+	return nil
+}
+
+func (c *errorDoesNotContainChecker) Args() []Arg {
+	return []Arg{{Name: "got error", Value: c.got}, {Name: "substr", Value: c.substr}}
+}
+
+// ErrorChainMatches is like ErrorMatches except that it succeeds if the
+// message of any error in the chain of the provided error, as walked with
+// errors.Unwrap, matches the provided regular expression pattern.
+// On failure, the message of every error in the chain is reported.
+func ErrorChainMatches[StringOrRegexp string | *regexp.Regexp](got error, want StringOrRegexp) Checker {
+	return &errorChainMatchesChecker{
+		got:   got,
+		want:  want,
+		match: newMatcher(want, true),
+	}
+}
+
+type errorChainMatchesChecker struct {
+	got   error
+	want  any
+	match matcher
+}
+
+func (c *errorChainMatchesChecker) Check(note func(key string, value any)) error {
+	if c.got == nil {
+		return errors.New("got nil error but want non-nil")
+	}
+	var chain []string
+	for depth, err := 0, c.got; err != nil; depth, err = depth+1, errors.Unwrap(err) {
+		matchErr := c.match(err.Error(), "error does not match regexp", note)
+		if matchErr == nil {
+			note("matched at depth", depth)
+			return nil
+		}
+		if IsBadCheck(matchErr) {
+			return matchErr
+		}
+		chain = append(chain, fmt.Sprintf("depth %d: %s", depth, quoteString(err.Error())))
+	}
+	note("error chain", Unquoted(strings.Join(chain, "\n")))
+	return errors.New("no error in chain matches regexp")
+}
+
+func (c *errorChainMatchesChecker) Args() []Arg {
+	return []Arg{{Name: "got error", Value: c.got}, {Name: "regexp", Value: c.want}}
+}
+
+// ErrorChainTypes returns a Checker checking that the dynamic types of the
+// errors in the chain of the provided error, as walked with errors.Unwrap,
+// are exactly the given types, in order from the outermost error. This is
+// useful for checking that errors are wrapped in the expected order, for
+// instance:
+//
+//	qt.Assert(t, qt.ErrorChainTypes(err,
+//		reflect.TypeOf((*url.Error)(nil)),
+//		reflect.TypeOf((*net.OpError)(nil)),
+//	))
+//
+// On failure, the types of the errors in the chain are reported.
+func ErrorChainTypes(got error, types ...reflect.Type) Checker {
+	return &errorChainTypesChecker{
+		got:   got,
+		types: types,
+	}
+}
+
+type errorChainTypesChecker struct {
+	got   error
+	types []reflect.Type
+}
+
+func (c *errorChainTypesChecker) Check(note func(key string, value any)) error {
+	if len(c.types) == 0 {
+		return BadCheckf("no types provided")
+	}
+	for i, t := range c.types {
+		if t == nil {
+			return BadCheckf("nil type provided at index %d", i)
+		}
+	}
+	if c.got == nil {
+		return errors.New("got nil error but want non-nil")
+	}
+	var chain []reflect.Type
+	for err := c.got; err != nil; err = errors.Unwrap(err) {
+		chain = append(chain, reflect.TypeOf(err))
+	}
+	if len(chain) == len(c.types) {
+		match := true
+		for i, t := range chain {
+			if t != c.types[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return nil
+		}
+	}
+	note("got types", Unquoted(typeChain(chain)))
+	return errors.New("error chain types do not match")
+}
+
+func (c *errorChainTypesChecker) Args() []Arg {
+	return []Arg{{Name: "got error", Value: c.got}, {Name: "want types", Value: Unquoted(typeChain(c.types))}}
+}
+
+// typeChain returns a description of the given error types, one per line.
+func typeChain(types []reflect.Type) string {
+	lines := make([]string, len(types))
+	for i, t := range types {
+		lines[i] = fmt.Sprintf("depth %d: %v", i, t)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ImplementsAll returns a Checker checking that the provided value implements
+// all the given interface types. On failure, each interface not implemented
+// is reported along with its missing methods.
+//
+// For instance:
+//
+//	qt.Assert(t, qt.ImplementsAll(f, reflect.TypeOf((*io.Reader)(nil)).Elem(), reflect.TypeOf((*io.Closer)(nil)).Elem()))
+func ImplementsAll(got any, interfaces ...reflect.Type) Checker {
+	return &implementsAllChecker{
+		got:   got,
+		wants: interfaces,
+	}
+}
+
+type implementsAllChecker struct {
+	got   any
+	wants []reflect.Type
+}
+
+func (c *implementsAllChecker) Check(note func(key string, value any)) error {
+	if len(c.wants) == 0 {
+		return BadCheckf("no interfaces provided")
+	}
+	for i, want := range c.wants {
+		if want == nil {
+			return BadCheckf("nil type provided at index %d", i)
+		}
+		if want.Kind() != reflect.Interface {
+			note("want interface", Unquoted(want.String()))
+			return BadCheckf("want an interface type but a concrete type was provided at index %d", i)
+		}
+	}
+	if c.got == nil {
+		note("error", Unquoted("got nil value but want non-nil"))
+		note("got", c.got)
+		return ErrSilent
+	}
+	gotType := reflect.TypeOf(c.got)
+	var lines []string
+	for _, want := range c.wants {
+		if gotType.Implements(want) {
+			continue
+		}
+		lines = append(lines, want.String())
+		for _, m := range missingMethods(gotType, want) {
+			lines = append(lines, "  "+m)
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	note("missing interfaces", Unquoted(strings.Join(lines, "\n")))
+	return errors.New("got value does not implement all wanted interfaces")
+}
+
+func (c *implementsAllChecker) Args() []Arg {
+	wants := make([]string, len(c.wants))
+	for i, want := range c.wants {
+		wants[i] = want.String()
+	}
+	return []Arg{{Name: "got", Value: c.got}, {Name: "want interfaces", Value: Unquoted(strings.Join(wants, "\n"))}}
+}
+
+// PanicMatches returns a Checker checking that the provided function panics
+// with a message matching the provided regular expression pattern.
+// (see [Matches] for more details on how the pattern is matched).
+func PanicMatches[StringOrRegexp string | *regexp.Regexp](f func(), want StringOrRegexp) Checker {
+	return &panicMatchesChecker{
+		got:   f,
+		want:  want,
+		match: newMatcher(want, true),
+	}
+}
+
+type panicMatchesChecker struct {
+	got   func()
+	want  any
+	match matcher
+}
+
+func (c *panicMatchesChecker) Check(note func(key string, value any)) (err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			err = errors.New("function did not panic")
+			return
+		}
+		msg := fmt.Sprint(r)
+		note("panic value", msg)
+		err = c.match(msg, "panic value does not match regexp", note)
+	}()
+	c.got()
+	return nil
+}
+
+func (c *panicMatchesChecker) Args() []Arg {
+	return []Arg{{Name: "function", Value: c.got}, {Name: "regexp", Value: c.want}}
+}
+
+// IsNil returns a Checker checking that the provided value is equal to nil.
+//
+// Note that an interface value containing a nil concrete
+// type is not considered to be nil.
+func IsNil[T any](got T) Checker {
+	return isNilChecker[T]{
+		got: got,
+	}
+}
+
+type isNilChecker[T any] struct {
+	got T
+}
+
+func (c isNilChecker[T]) Check(note func(key string, value any)) error {
+	v := reflect.ValueOf(&c.got).Elem()
+	if !canBeNil(v.Kind()) {
+		return BadCheckf("type %v can never be nil", v.Type())
+	}
+	if v.IsNil() {
+		return nil
+	}
+	if v.Kind() == reflect.Interface {
+		// Explain the classic Go gotcha of an interface holding a nil pointer.
+		if e := v.Elem(); canBeNil(e.Kind()) && e.IsNil() {
+			note("note", Unquoted(fmt.Sprintf("value is a non-nil interface wrapping a nil %s", e.Type())))
+		}
+	}
+	return errors.New("got non-nil value")
+}
+
+func (c isNilChecker[T]) Args() []Arg {
+	return []Arg{{Name: "got", Value: c.got}}
+}
+
+func (c isNilChecker[T]) negatedError() error {
+	v := reflect.ValueOf(c.got)
+	if v.IsValid() {
+		return fmt.Errorf("got nil %s but want non-nil", v.Kind())
+	}
+	return errors.New("got <nil> but want non-nil")
+}
+
+// IsNotNil returns a Checker checking that the provided value is not nil.
+// IsNotNil(v) is the equivalent of qt.Not(qt.IsNil(v)).
+func IsNotNil[T any](got T) Checker {
+	return Not(IsNil(got))
+}
+
+// IsNilSlice returns a Checker checking that the provided slice is nil. Unlike
+// IsNil, it distinguishes a nil slice from an empty one in the failure
+// message, which is useful when nil-ness is significant, for instance when
+// a nil slice is encoded as JSON null rather than [].
+func IsNilSlice[T any](got []T) Checker {
+	return &isNilContainerChecker{
+		got:   got,
+		kind:  "slice",
+		isNil: got == nil,
+		len:   len(got),
+	}
+}
+
+// IsNilMap returns a Checker checking that the provided map is nil. Unlike
+// IsNil, it distinguishes a nil map from an empty one in the failure message.
+func IsNilMap[K comparable, V any](got map[K]V) Checker {
+	return &isNilContainerChecker{
+		got:   got,
+		kind:  "map",
+		isNil: got == nil,
+		len:   len(got),
+	}
+}
+
+type isNilContainerChecker struct {
+	got   any
+	kind  string
+	isNil bool
+	len   int
+}
+
+func (c *isNilContainerChecker) Check(note func(key string, value any)) error {
+	if c.isNil {
+		return nil
+	}
+	if c.len == 0 {
+		return fmt.Errorf("%s is non-nil (it is an empty %s)", c.kind, c.kind)
+	}
+	return fmt.Errorf("%s is non-nil (it has length %d)", c.kind, c.len)
+}
+
+func (c *isNilContainerChecker) Args() []Arg {
+	return []Arg{{Name: "got", Value: c.got}}
+}
+
+func (c *isNilContainerChecker) negatedError() error {
+	return fmt.Errorf("got nil %s but want non-nil", c.kind)
+}
+
+// HasLen returns a Checker checking that the provided value has the given
+// length. The value may be a slice, array, pointer to array, channel, map or
+// string.
+//
+// If the value is an interface, the length of its dynamic value is checked,
+// so HasLen(any([]int{1, 2}), 2) succeeds. A nil interface value, or one
+// holding a value with no length, is reported as a bad check.
+func HasLen[T any](got T, n int) Checker {
+	return &hasLenChecker[T]{
+		got:     got,
+		wantLen: n,
+	}
+}
+
+type hasLenChecker[T any] struct {
+	got     T
+	wantLen int
+}
+
+func (c *hasLenChecker[T]) Check(note func(key string, value any)) (err error) {
+	v := reflect.ValueOf(&c.got).Elem()
+	if v.Kind() == reflect.Interface {
+		// Check the length of the dynamic value.
+		if v.IsNil() {
+			note("got", c.got)
+			return BadCheckf("first argument of type %v is nil and has no length", v.Type())
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+	case reflect.Pointer:
+		// Allow a pointer to array.
+		if v.Elem().Kind() == reflect.Array {
+			break
+		}
+
+		// Error on all other pointers.
+		note("got", c.got)
+		return BadCheckf("first argument of type %v has no length", v.Type())
+	default:
+		note("got", c.got)
+		return BadCheckf("first argument of type %v has no length", v.Type())
+	}
+	length := v.Len()
+	note("len(got)", length)
+	if length != c.wantLen {
+		return fmt.Errorf("unexpected length")
+	}
+	return nil
+}
+
+func (c *hasLenChecker[T]) Args() []Arg {
+	return []Arg{{Name: "got", Value: c.got}, {Name: "want length", Value: c.wantLen}}
+}
+
+// ChannelLen returns a Checker checking that the provided channel holds
+// exactly want buffered items, that is, that len(ch) == want. This is useful
+// for checking the state of buffers in channel-based pipelines. Both
+// bidirectional and receive-only channels can be provided.
+//
+// Note that the length of a channel can change at any time when other
+// goroutines are sending or receiving on it.
+func ChannelLen[T any](ch <-chan T, want int) Checker {
+	return &channelLenChecker[T]{
+		ch:   ch,
+		want: want,
+	}
+}
+
+// ChannelEmpty returns a Checker checking that the provided channel holds no
+// buffered items. Unbuffered and nil channels are always empty.
+func ChannelEmpty[T any](ch <-chan T) Checker {
+	return &channelLenChecker[T]{
+		ch:   ch,
+		want: 0,
+	}
+}
+
+// ChannelFull returns a Checker checking that the buffer of the provided
+// channel is full, so that a send would block until an item is received.
+// Checking an unbuffered channel is a bad check.
+func ChannelFull[T any](ch <-chan T) Checker {
+	return &channelLenChecker[T]{
+		ch:   ch,
+		full: true,
+	}
+}
+
+type channelLenChecker[T any] struct {
+	ch   <-chan T
+	want int
+	// full reports whether the channel is expected to be full, in which case
+	// want is ignored.
+	full bool
+}
+
+func (c *channelLenChecker[T]) Check(note func(key string, value any)) error {
+	n := len(c.ch)
+	if !c.full {
+		if c.want < 0 {
+			return BadCheckf("invalid length %d", c.want)
+		}
+		if n == c.want {
+			return nil
+		}
+		return fmt.Errorf("channel has %d buffered items, want %d", n, c.want)
+	}
+	if cap(c.ch) == 0 {
+		return BadCheckf("unbuffered channel provided")
+	}
+	if n == cap(c.ch) {
+		return nil
+	}
+	return fmt.Errorf("channel is not full: it has %d buffered items, want %d", n, cap(c.ch))
+}
+
+func (c *channelLenChecker[T]) Args() []Arg {
+	return []Arg{{Name: "length", Value: len(c.ch)}, {Name: "capacity", Value: cap(c.ch)}}
+}
+
+// Implements returns a Checker checking that the provided value implements the
+// interface specified by the type parameter.
+func Implements[I any](got any) Checker {
+	return &implementsChecker{
+		got:  got,
+		want: typeOf[I](),
 	}
 }
 
@@ -354,142 +2074,1382 @@ type implementsChecker struct {
 	want reflect.Type
 }
 
-func (c *implementsChecker) Check(note func(key string, value any)) (err error) {
-	if c.got == nil {
-		note("error", Unquoted("got nil value but want non-nil"))
-		note("got", c.got)
+func (c *implementsChecker) Check(note func(key string, value any)) (err error) {
+	if c.got == nil {
+		note("error", Unquoted("got nil value but want non-nil"))
+		note("got", c.got)
+		return ErrSilent
+	}
+	if c.want.Kind() != reflect.Interface {
+		note("want interface", Unquoted(c.want.String()))
+		return BadCheckf("want an interface type but a concrete type was provided")
+	}
+
+	gotType := reflect.TypeOf(c.got)
+	if !gotType.Implements(c.want) {
+		if missing := missingMethods(gotType, c.want); len(missing) > 0 {
+			note("missing methods", Unquoted(strings.Join(missing, "\n")))
+		}
+		return fmt.Errorf("got value does not implement wanted interface")
+	}
+
+	return nil
+}
+
+// missingMethods returns a description of each method of the interface iface
+// that is missing from t or that is defined on t with the wrong signature.
+func missingMethods(t, iface reflect.Type) []string {
+	var missing []string
+	for i := 0; i < iface.NumMethod(); i++ {
+		want := iface.Method(i)
+		desc := want.Name + strings.TrimPrefix(want.Type.String(), "func")
+		got, ok := t.MethodByName(want.Name)
+		if !ok {
+			if t.Kind() != reflect.Pointer && t.Kind() != reflect.Interface {
+				if _, ok := reflect.PointerTo(t).MethodByName(want.Name); ok {
+					missing = append(missing, desc+": defined on pointer receiver")
+					continue
+				}
+			}
+			missing = append(missing, desc+": missing")
+			continue
+		}
+		gotType := methodType(t, got)
+		if gotType != want.Type {
+			missing = append(missing, desc+": has wrong signature "+want.Name+strings.TrimPrefix(gotType.String(), "func"))
+		}
+	}
+	return missing
+}
+
+// methodType returns the type of the given method of t, without the
+// receiver argument.
+func methodType(t reflect.Type, m reflect.Method) reflect.Type {
+	if t.Kind() == reflect.Interface {
+		// Methods of interface types have no receiver.
+		return m.Type
+	}
+	in := make([]reflect.Type, 0, m.Type.NumIn()-1)
+	for i := 1; i < m.Type.NumIn(); i++ {
+		in = append(in, m.Type.In(i))
+	}
+	out := make([]reflect.Type, 0, m.Type.NumOut())
+	for i := 0; i < m.Type.NumOut(); i++ {
+		out = append(out, m.Type.Out(i))
+	}
+	return reflect.FuncOf(in, out, m.Type.IsVariadic())
+}
+
+func (c *implementsChecker) Args() []Arg {
+	return []Arg{{Name: "got", Value: c.got}, {Name: "want interface", Value: Unquoted(c.want.String())}}
+
+}
+
+// Satisfies returns a Checker checking that the provided value, when used as
+// argument of the provided predicate function, causes the function to return
+// true.
+func Satisfies[T any](got T, f func(T) bool) Checker {
+	return &satisfiesChecker[T]{
+		got:       got,
+		predicate: f,
+	}
+}
+
+type satisfiesChecker[T any] struct {
+	got       T
+	predicate func(T) bool
+}
+
+// Check implements Checker.Check by checking that args[0](got) == true.
+func (c *satisfiesChecker[T]) Check(note func(key string, value any)) error {
+	if c.predicate(c.got) {
+		return nil
+	}
+	return fmt.Errorf("value does not satisfy predicate function")
+}
+
+func (c *satisfiesChecker[T]) Args() []Arg {
+	return []Arg{{
+		Name:  "got",
+		Value: c.got,
+	}, {
+		Name:  "predicate",
+		Value: c.predicate,
+	}}
+}
+
+// SatisfiesErr is like Satisfies except that the provided predicate function
+// returns an error rather than a boolean. The check succeeds if the function
+// returns a nil error; otherwise the error message is reported as the reason
+// for the failure.
+func SatisfiesErr[T any](got T, f func(T) error) Checker {
+	return &satisfiesErrChecker[T]{
+		got:       got,
+		predicate: f,
+	}
+}
+
+type satisfiesErrChecker[T any] struct {
+	got       T
+	predicate func(T) error
+}
+
+func (c *satisfiesErrChecker[T]) Check(note func(key string, value any)) error {
+	if err := c.predicate(c.got); err != nil {
+		return fmt.Errorf("value does not satisfy predicate function: %s", err)
+	}
+	return nil
+}
+
+func (c *satisfiesErrChecker[T]) Args() []Arg {
+	return []Arg{{
+		Name:  "got",
+		Value: c.got,
+	}, {
+		Name:  "predicate",
+		Value: c.predicate,
+	}}
+}
+
+// Changes returns a Checker checking that calling the provided function
+// changes the value pointed to by ptr so that it equals want afterwards.
+// Values are compared as in DeepEquals. On failure, the value before the call
+// is reported along with a diff between the value after the call and want.
+// The value is deep-copied before calling the function, as in Unchanged, so
+// that changes made in place are not reflected in the reported value before
+// the call.
+//
+// Note that the function is called each time the check is run.
+func Changes[T any](ptr *T, by func(), want T) Checker {
+	return &changesChecker[T]{
+		ptr:  ptr,
+		by:   by,
+		want: want,
+	}
+}
+
+type changesChecker[T any] struct {
+	ptr  *T
+	by   func()
+	want T
+}
+
+func (c *changesChecker[T]) Check(note func(key string, value any)) error {
+	if c.ptr == nil {
+		return BadCheckf("nil pointer provided")
+	}
+	if c.by == nil {
+		return BadCheckf("nil function provided")
+	}
+	before := deepCopy(*c.ptr)
+	c.by()
+	relay := relayDeepEqualsNotes(note, "value did not change as expected", "after", "want")
+	err := DeepEquals(*c.ptr, c.want).Check(func(key string, value any) {
+		relay(key, value)
+		if key == "error" {
+			note("before", before)
+		}
+	})
+	if err == nil {
+		// Report the observed values in case the checker is negated.
+		note("before", before)
+		note("after", *c.ptr)
+	}
+	return err
+}
+
+func (c *changesChecker[T]) Args() []Arg {
+	return []Arg{{Name: "want", Value: c.want}}
+}
+
+// Increments returns a Checker checking that calling the provided function
+// changes the number pointed to by ptr by the given delta, which may be
+// negative. On failure, the values before and after the call are reported.
+//
+// Note that the function is called each time the check is run.
+func Increments[T number](ptr *T, by func(), delta T) Checker {
+	return &incrementsChecker[T]{
+		ptr:   ptr,
+		by:    by,
+		delta: delta,
+	}
+}
+
+type incrementsChecker[T number] struct {
+	ptr   *T
+	by    func()
+	delta T
+}
+
+func (c *incrementsChecker[T]) Check(note func(key string, value any)) error {
+	if c.ptr == nil {
+		return BadCheckf("nil pointer provided")
+	}
+	if c.by == nil {
+		return BadCheckf("nil function provided")
+	}
+	before := *c.ptr
+	c.by()
+	after := *c.ptr
+	note("before", before)
+	note("after", after)
+	if after-before == c.delta {
+		return nil
+	}
+	note("delta", after-before)
+	return errors.New("value did not change by the expected delta")
+}
+
+func (c *incrementsChecker[T]) Args() []Arg {
+	return []Arg{{Name: "want delta", Value: c.delta}}
+}
+
+// Unchanged returns a Checker checking that calling the provided function
+// does not change the value pointed to by ptr. This is useful for checking
+// that an operation supposed to be read-only has no side effects. Values are
+// compared as in DeepEquals, and on failure a diff between the values before
+// and after the call is reported.
+//
+// The value is deep-copied before calling the function, so that changes made
+// through pointers, slices and maps are detected. Map keys, unexported
+// struct fields, channels and functions are shared rather than copied, so
+// changes made through them are not detected.
+//
+// Note that the function is called each time the check is run.
+func Unchanged[T any](ptr *T, op func()) Checker {
+	return &unchangedChecker[T]{
+		ptr: ptr,
+		op:  op,
+	}
+}
+
+type unchangedChecker[T any] struct {
+	ptr *T
+	op  func()
+}
+
+func (c *unchangedChecker[T]) Check(notef func(key string, value any)) error {
+	if c.ptr == nil {
+		return BadCheckf("nil pointer provided")
+	}
+	if c.op == nil {
+		return BadCheckf("nil function provided")
+	}
+	before := deepCopy(*c.ptr)
+	c.op()
+	return DeepEquals(*c.ptr, before).Check(relayDeepEqualsNotes(notef, "value changed", "after", "before"))
+}
+
+func (c *unchangedChecker[T]) Args() []Arg {
+	var value any
+	if c.ptr != nil {
+		value = *c.ptr
+	}
+	return []Arg{{Name: "value", Value: value}}
+}
+
+// deepCopy returns a deep copy of v. See Unchanged for the limitations.
+func deepCopy[T any](v T) T {
+	var dst T
+	copyValue(reflect.ValueOf(&dst).Elem(), reflect.ValueOf(&v).Elem(), make(map[copyKey]reflect.Value))
+	return dst
+}
+
+// copyKey identifies a pointer already copied by copyValue, so that cyclic
+// values can be copied.
+type copyKey struct {
+	t reflect.Type
+	p uintptr
+}
+
+// copyValue deep-copies src into dst, which must be settable.
+func copyValue(dst, src reflect.Value, seen map[copyKey]reflect.Value) {
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+		k := copyKey{src.Type(), src.Pointer()}
+		if p, ok := seen[k]; ok {
+			dst.Set(p)
+			return
+		}
+		p := reflect.New(src.Type().Elem())
+		seen[k] = p
+		copyValue(p.Elem(), src.Elem(), seen)
+		dst.Set(p)
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		s := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			copyValue(s.Index(i), src.Index(i), seen)
+		}
+		dst.Set(s)
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			copyValue(dst.Index(i), src.Index(i), seen)
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		m := reflect.MakeMapWithSize(src.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			// Keys are compared by identity when looking up map entries, so
+			// they are not copied.
+			v := reflect.New(src.Type().Elem()).Elem()
+			copyValue(v, iter.Value(), seen)
+			m.SetMapIndex(iter.Key(), v)
+		}
+		dst.Set(m)
+	case reflect.Struct:
+		// Copy all fields shallowly, then deep-copy the exported ones.
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if src.Type().Field(i).IsExported() {
+				copyValue(dst.Field(i), src.Field(i), seen)
+			}
+		}
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		e := reflect.New(src.Elem().Type()).Elem()
+		copyValue(e, src.Elem(), seen)
+		dst.Set(e)
+	default:
+		dst.Set(src)
+	}
+}
+
+// IsTrue returns a Checker checking that the provided value is true.
+func IsTrue[T ~bool](got T) Checker {
+	return Equals(got, true)
+}
+
+// IsFalse returns a Checker checking that the provided value is false.
+func IsFalse[T ~bool](got T) Checker {
+	return Equals(got, false)
+}
+
+// IsPositive returns a Checker checking that the provided value is strictly
+// greater than zero.
+func IsPositive[T signed](got T) Checker {
+	return &signChecker[T]{
+		got:  got,
+		desc: "positive",
+		ok:   func(v T) bool { return v > 0 },
+	}
+}
+
+// IsNegative returns a Checker checking that the provided value is strictly
+// less than zero.
+func IsNegative[T signed](got T) Checker {
+	return &signChecker[T]{
+		got:  got,
+		desc: "negative",
+		ok:   func(v T) bool { return v < 0 },
+	}
+}
+
+// IsNonNegative returns a Checker checking that the provided value is greater
+// than or equal to zero.
+func IsNonNegative[T signed](got T) Checker {
+	return &signChecker[T]{
+		got:  got,
+		desc: "non-negative",
+		ok:   func(v T) bool { return v >= 0 },
+	}
+}
+
+type signChecker[T signed] struct {
+	got  T
+	desc string
+	ok   func(T) bool
+}
+
+func (c *signChecker[T]) Check(note func(key string, value any)) error {
+	// Note that NaN values are neither positive, negative nor non-negative.
+	if c.ok(c.got) {
+		return nil
+	}
+	return fmt.Errorf("value is not %s", c.desc)
+}
+
+func (c *signChecker[T]) Args() []Arg {
+	return []Arg{{Name: "got", Value: c.got}}
+}
+
+// IsMultipleOf returns a Checker checking that the provided value is a
+// multiple of factor, that is, that got%factor == 0. This is useful for
+// checking alignment, for instance that a buffer size is a multiple of the
+// page size. On failure, the remainder is reported.
+func IsMultipleOf[T integer](got, factor T) Checker {
+	return &isMultipleOfChecker[T]{
+		got:    got,
+		factor: factor,
+	}
+}
+
+type isMultipleOfChecker[T integer] struct {
+	got, factor T
+}
+
+func (c *isMultipleOfChecker[T]) Check(note func(key string, value any)) error {
+	if c.factor == 0 {
+		return BadCheckf("factor is zero")
+	}
+	if rem := c.got % c.factor; rem != 0 {
+		note("remainder", rem)
+		return errors.New("value is not a multiple of factor")
+	}
+	return nil
+}
+
+func (c *isMultipleOfChecker[T]) Args() []Arg {
+	return []Arg{{Name: "got", Value: c.got}, {Name: "factor", Value: c.factor}}
+}
+
+// ApproximatelyPercent returns a Checker checking that got differs from want
+// by at most the given percentage of want, that is, that
+// |got-want| <= |want|*percent/100. This is useful when the acceptable error
+// scales with the magnitude of the values, for instance when comparing
+// measurements.
+//
+// When want is zero, no relative error can be computed, so got must be zero
+// too.
+func ApproximatelyPercent(got, want, percent float64) Checker {
+	return &approximatelyPercentChecker{
+		got:     got,
+		want:    want,
+		percent: percent,
+	}
+}
+
+type approximatelyPercentChecker struct {
+	got, want, percent float64
+}
+
+func (c *approximatelyPercentChecker) Check(note func(key string, value any)) error {
+	if c.percent < 0 || math.IsNaN(c.percent) {
+		return BadCheckf("invalid percentage %v", c.percent)
+	}
+	diff := math.Abs(c.got - c.want)
+	if diff <= math.Abs(c.want)*c.percent/100 {
+		return nil
+	}
+	note("difference", Unquoted(fmt.Sprintf("%g%%", diff/math.Abs(c.want)*100)))
+	return errors.New("values differ by more than the allowed percentage")
+}
+
+func (c *approximatelyPercentChecker) Args() []Arg {
+	return []Arg{{
+		Name:  "got",
+		Value: c.got,
+	}, {
+		Name:  "want",
+		Value: c.want,
+	}, {
+		Name:  "allowed difference",
+		Value: Unquoted(fmt.Sprintf("%g%%", c.percent)),
+	}}
+}
+
+// SliceApproximately returns a Checker checking that the two slices have the
+// same length and that each element of got differs from the corresponding
+// element of want by at most the given tolerance. This is useful when
+// checking the results of floating point computations, for which DeepEquals
+// fails because of rounding errors. On failure, the first index at which the
+// tolerance is exceeded is reported, along with both elements and their
+// difference.
+//
+// To compare floating point numbers nested in other values, or using a
+// relative tolerance, use DeepEqualsApprox.
+func SliceApproximately[T float](got, want []T, tolerance T) Checker {
+	return &sliceApproximatelyChecker[T]{
+		argPair:   argPairOf(got, want),
+		tolerance: tolerance,
+	}
+}
+
+type sliceApproximatelyChecker[T float] struct {
+	argPair[[]T, []T]
+	tolerance T
+}
+
+func (c *sliceApproximatelyChecker[T]) Check(note func(key string, value any)) error {
+	if c.tolerance < 0 || c.tolerance != c.tolerance {
+		return BadCheckf("invalid tolerance %v", c.tolerance)
+	}
+	if len(c.got) != len(c.want) {
+		note("got length", len(c.got))
+		note("want length", len(c.want))
+		return errors.New("slices have different lengths")
+	}
+	for i, got := range c.got {
+		want := c.want[i]
+		diff := got - want
+		if diff < 0 {
+			diff = -diff
+		}
+		// The negated comparison makes NaN values fail.
+		if !(diff <= c.tolerance) {
+			note("index", i)
+			note("got element", got)
+			note("want element", want)
+			note("difference", diff)
+			return errors.New("element differs by more than the tolerance")
+		}
+	}
+	return nil
+}
+
+func (c *sliceApproximatelyChecker[T]) Args() []Arg {
+	return append(c.argPair.Args(), Arg{Name: "tolerance", Value: c.tolerance})
+}
+
+// WithinDuration returns a Checker checking that the provided times differ by
+// at most the given tolerance, in either direction.
+//
+// Monotonic clock readings are stripped before comparing, so that the
+// difference is always computed between wall clock instants.
+func WithinDuration(got, want time.Time, tolerance time.Duration) Checker {
+	return &withinDurationChecker{
+		argPair:   argPairOf(got, want),
+		tolerance: tolerance,
+	}
+}
+
+type withinDurationChecker struct {
+	argPair[time.Time, time.Time]
+	tolerance time.Duration
+}
+
+func (c *withinDurationChecker) Check(note func(key string, value any)) error {
+	if c.tolerance < 0 {
+		return BadCheckf("negative tolerance %v", c.tolerance)
+	}
+	diff := c.got.Round(0).Sub(c.want.Round(0))
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff <= c.tolerance {
+		return nil
+	}
+	note("difference", diff)
+	return errors.New("time difference exceeds tolerance")
+}
+
+func (c *withinDurationChecker) Args() []Arg {
+	return append(c.argPair.Args(), Arg{
+		Name:  "tolerance",
+		Value: c.tolerance,
+	})
+}
+
+// TimeEquals returns a Checker checking that the provided times represent the
+// same instant, as reported by time.Time.Equal. Unlike DeepEquals, it ignores
+// monotonic clock readings and locations, so that for instance a time and
+// the same time converted to UTC are considered equal.
+func TimeEquals(got, want time.Time) Checker {
+	return &timeEqualsChecker{
+		argPair: argPairOf(got, want),
+	}
+}
+
+type timeEqualsChecker struct {
+	argPair[time.Time, time.Time]
+}
+
+func (c *timeEqualsChecker) Check(note func(key string, value any)) error {
+	if c.got.Equal(c.want) {
+		return nil
+	}
+	note("difference", c.got.Sub(c.want))
+	note("got UnixNano", c.got.UnixNano())
+	note("want UnixNano", c.want.UnixNano())
+	return errors.New("times are not equal")
+}
+
+// EqualsMethod returns a Checker checking that got.Equal(want) returns true.
+// This is useful for types defining their own notion of equality, such as
+// time.Time or net.IP, for which Equals may panic or be too strict and
+// DeepEquals may compare irrelevant internal details.
+func EqualsMethod[T interface{ Equal(T) bool }](got, want T) Checker {
+	return &equalsMethodChecker[T]{
+		argPair: argPairOf(got, want),
+	}
+}
+
+type equalsMethodChecker[T interface{ Equal(T) bool }] struct {
+	argPair[T, T]
+}
+
+func (c *equalsMethodChecker[T]) Check(note func(key string, value any)) error {
+	if c.got.Equal(c.want) {
+		return nil
+	}
+	return errors.New("values are not equal according to their Equal method")
+}
+
+// InThePast returns a Checker checking that the provided time is before the
+// current time, as reported by time.Now when the check is run. On failure,
+// the distance from the current time is reported.
+func InThePast(got time.Time) Checker {
+	return &relativeTimeChecker{
+		got:    got,
+		future: false,
+	}
+}
+
+// InTheFuture returns a Checker checking that the provided time is after the
+// current time, as reported by time.Now when the check is run. On failure,
+// the distance from the current time is reported.
+//
+// For instance:
+//
+//	qt.Assert(t, qt.InTheFuture(tok.Expiry))
+func InTheFuture(got time.Time) Checker {
+	return &relativeTimeChecker{
+		got:    got,
+		future: true,
+	}
+}
+
+// timeNow is used by InThePast and InTheFuture to retrieve the current time.
+var timeNow = time.Now
+
+type relativeTimeChecker struct {
+	got    time.Time
+	future bool
+}
+
+func (c *relativeTimeChecker) Check(note func(key string, value any)) error {
+	now := timeNow()
+	note("now", now)
+	if c.future && c.got.After(now) || !c.future && c.got.Before(now) {
+		return nil
+	}
+	want := "past"
+	if c.future {
+		want = "future"
+	}
+	var when string
+	switch d := c.got.Sub(now); {
+	case d < 0:
+		when = fmt.Sprintf("it was %v ago", -d)
+	case d > 0:
+		when = fmt.Sprintf("it is in %v", d)
+	default:
+		when = "it is now"
+	}
+	return fmt.Errorf("time is not in the %s (%s)", want, when)
+}
+
+func (c *relativeTimeChecker) Args() []Arg {
+	return []Arg{{Name: "got", Value: c.got}}
+}
+
+// DurationEquals returns a Checker checking that the provided durations are
+// equal. On failure, the difference between the two durations is reported,
+// and all durations are shown in human readable form, for instance "1.5s".
+func DurationEquals(got, want time.Duration) Checker {
+	return DurationWithin(got, want, 0)
+}
+
+// DurationWithin returns a Checker checking that the provided durations
+// differ by at most the given tolerance, in either direction.
+func DurationWithin(got, want, tolerance time.Duration) Checker {
+	return &durationChecker{
+		argPair:   argPairOf(got, want),
+		tolerance: tolerance,
+	}
+}
+
+type durationChecker struct {
+	argPair[time.Duration, time.Duration]
+	tolerance time.Duration
+}
+
+func (c *durationChecker) Check(note func(key string, value any)) error {
+	if c.tolerance < 0 {
+		return BadCheckf("negative tolerance %v", c.tolerance)
+	}
+	diff := c.got - c.want
+	if diff >= -c.tolerance && diff <= c.tolerance {
+		return nil
+	}
+	note("difference", diff)
+	if c.tolerance == 0 {
+		return errors.New("durations are not equal")
+	}
+	return errors.New("duration difference exceeds tolerance")
+}
+
+func (c *durationChecker) Args() []Arg {
+	args := c.argPair.Args()
+	if c.tolerance == 0 {
+		return args
+	}
+	return append(args, Arg{
+		Name:  "tolerance",
+		Value: c.tolerance,
+	})
+}
+
+// Not returns a Checker negating the given Checker.
+func Not(c Checker) Checker {
+	// Not(Not(c)) becomes c.
+	if c, ok := c.(notChecker); ok {
+		return c.Checker
+	}
+	return notChecker{
+		Checker: c,
+	}
+}
+
+type notChecker struct {
+	Checker
+}
+
+func (c notChecker) Check(note func(key string, value any)) error {
+	err := c.Checker.Check(note)
+	if IsBadCheck(err) {
+		return err
+	}
+	if err != nil {
+		return nil
+	}
+	if c, ok := c.Checker.(negatedError); ok {
+		return c.negatedError()
+	}
+	return errors.New("unexpected success")
+}
+
+// All returns a Checker that succeeds if all the given checkers succeed.
+// The checkers are run in order and the first failure is reported along
+// with the position of the failing checker, which is useful when the same
+// kind of checker is used more than once.
+//
+// A bad check from any of the given checkers stops the check immediately
+// and is reported as a bad check for the whole group.
+func All(checkers ...Checker) Checker {
+	return &allOfChecker{
+		checkers: checkers,
+	}
+}
+
+type allOfChecker struct {
+	checkers []Checker
+}
+
+func (c *allOfChecker) Check(notef func(key string, value any)) error {
+	for i, checker := range c.checkers {
+		if checker == nil {
+			return BadCheckf("check %d of %d: nil checker provided", i+1, len(c.checkers))
+		}
+		notes, err := checkCollectingNotes(checker)
+		if err == nil {
+			continue
+		}
+		if IsBadCheck(err) {
+			return BadCheckf("check %d of %d: %v", i+1, len(c.checkers), err)
+		}
+		notef("error", Unquoted(fmt.Sprintf("check %d of %d failed", i+1, len(c.checkers))))
+		if err != ErrSilent {
+			notef("error", Unquoted(err.Error()))
+		}
+		for _, n := range notes {
+			notef(n.key, n.value)
+		}
+		if err != ErrSilent {
+			// The checker is expecting the caller to print its arguments.
+			for _, arg := range checker.Args() {
+				notef(arg.Name, arg.Value)
+			}
+		}
 		return ErrSilent
 	}
-	if c.want.Kind() != reflect.Interface {
-		note("want interface", Unquoted(c.want.String()))
-		return BadCheckf("want an interface type but a concrete type was provided")
+	return nil
+}
+
+func (c *allOfChecker) Args() []Arg {
+	var args []Arg
+	for i, checker := range c.checkers {
+		if checker == nil {
+			continue
+		}
+		for _, arg := range checker.Args() {
+			args = append(args, Arg{
+				Name:  fmt.Sprintf("check %d %s", i+1, arg.Name),
+				Value: arg.Value,
+			})
+		}
+	}
+	return args
+}
+
+// StringContains returns a Checker checking that the given string contains the
+// given substring.
+func StringContains[T ~string](got, substr T) Checker {
+	return &stringContainsChecker[T]{
+		got:    got,
+		substr: substr,
+	}
+}
+
+type stringContainsChecker[T ~string] struct {
+	got, substr T
+}
+
+func (c *stringContainsChecker[T]) Check(note func(key string, value any)) error {
+	if strings.Contains(string(c.got), string(c.substr)) {
+		return nil
+	}
+	return errors.New("no substring match found")
+}
+
+func (c *stringContainsChecker[T]) Args() []Arg {
+	return []Arg{{
+		Name:  "got",
+		Value: c.got,
+	}, {
+		Name:  "substr",
+		Value: c.substr,
+	}}
+}
+
+// EqualsTrimmed returns a Checker checking that the given strings are equal
+// after removing leading and trailing white space from both, as with
+// strings.TrimSpace. This is useful when comparing command output or rendered
+// templates where trailing new lines may vary.
+//
+// On failure, the original values are reported with white space made visible.
+func EqualsTrimmed(got, want string) Checker {
+	return &equalsTrimmedChecker{
+		argPair: argPairOf(got, want),
+	}
+}
+
+type equalsTrimmedChecker struct {
+	argPair[string, string]
+}
+
+func (c *equalsTrimmedChecker) Check(note func(key string, value any)) error {
+	got, want := strings.TrimSpace(c.got), strings.TrimSpace(c.want)
+	if got == want {
+		return nil
+	}
+	noteLineDiff(got, want, note)
+	return errors.New("values are not equal after trimming white space")
+}
+
+func (c *equalsTrimmedChecker) Args() []Arg {
+	return []Arg{{
+		Name:  "got",
+		Value: Unquoted(visibleWhitespace(c.got)),
+	}, {
+		Name:  "want",
+		Value: Unquoted(visibleWhitespace(c.want)),
+	}}
+}
+
+// whitespaceReplacer makes white space visible: spaces are shown as "·",
+// tabs as "→", carriage returns as "␍" and new lines as "↵" followed by an
+// actual new line.
+var whitespaceReplacer = strings.NewReplacer(
+	" ", "·",
+	"\t", "→",
+	"\r", "␍",
+	"\n", "↵\n",
+)
+
+// visibleWhitespace returns s with white space made visible.
+func visibleWhitespace(s string) string {
+	return whitespaceReplacer.Replace(s)
+}
+
+// LinesEqual returns a Checker checking that the given readers produce the
+// same lines. Line endings are normalized before comparing, so that "\r\n"
+// and "\n" are considered equal, and a final line ending is ignored. Use
+// strings.NewReader to compare strings.
+//
+// On failure, the number of the first differing line is reported along with
+// the got and want versions of that line.
+func LinesEqual(got, want io.Reader) Checker {
+	return &linesEqualChecker{
+		got:  got,
+		want: want,
+	}
+}
+
+type linesEqualChecker struct {
+	got, want           io.Reader
+	gotLines, wantLines []string
+	// read holds whether the readers have been consumed, so that the
+	// checker can be run more than once.
+	read bool
+}
+
+func (c *linesEqualChecker) Check(note func(key string, value any)) error {
+	if !c.read {
+		var err error
+		if c.gotLines, err = readLines(c.got); err != nil {
+			return BadCheckf("cannot read got: %v", err)
+		}
+		if c.wantLines, err = readLines(c.want); err != nil {
+			return BadCheckf("cannot read want: %v", err)
+		}
+		c.read = true
+	}
+	for i := 0; i < len(c.gotLines) && i < len(c.wantLines); i++ {
+		if c.gotLines[i] != c.wantLines[i] {
+			note("line number", i+1)
+			note("got line", c.gotLines[i])
+			note("want line", c.wantLines[i])
+			return errors.New("lines are not equal")
+		}
+	}
+	n := len(c.gotLines)
+	switch {
+	case n < len(c.wantLines):
+		note("line number", n+1)
+		note("want line", c.wantLines[n])
+	case n > len(c.wantLines):
+		n = len(c.wantLines)
+		note("line number", n+1)
+		note("got line", c.gotLines[n])
+	default:
+		return nil
+	}
+	return fmt.Errorf("got %d lines, want %d", len(c.gotLines), len(c.wantLines))
+}
+
+func (c *linesEqualChecker) Args() []Arg {
+	return []Arg{{
+		Name:  "got",
+		Value: joinLines(c.gotLines),
+	}, {
+		Name:  "want",
+		Value: joinLines(c.wantLines),
+	}}
+}
+
+func joinLines(lines []string) Unquoted {
+	if len(lines) == 0 {
+		return "no lines"
+	}
+	return Unquoted(strings.Join(lines, "\n"))
+}
+
+// readLines reads all the lines from r, normalizing line endings.
+func readLines(r io.Reader) ([]string, error) {
+	if r == nil {
+		return nil, errors.New("nil reader")
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	s := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if s == "" {
+		return nil, nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n"), nil
+}
+
+// IsValidUTF8 returns a Checker checking that the given string is valid
+// UTF-8. On failure, the offset of the first invalid byte is reported along
+// with a hex dump of the surrounding bytes.
+func IsValidUTF8(got string) Checker {
+	return &isValidUTF8Checker{
+		got: got,
+	}
+}
+
+type isValidUTF8Checker struct {
+	got string
+}
+
+func (c *isValidUTF8Checker) Check(note func(key string, value any)) error {
+	for i := 0; i < len(c.got); {
+		r, size := utf8.DecodeRuneInString(c.got[i:])
+		if r == utf8.RuneError && size == 1 {
+			note("invalid byte at offset", Unquoted(fmt.Sprintf("%d (%#x)", i, i)))
+			note("context", Unquoted(hexDump([]byte(c.got), i)))
+			return errors.New("string is not valid UTF-8")
+		}
+		i += size
+	}
+	return nil
+}
+
+func (c *isValidUTF8Checker) Args() []Arg {
+	return []Arg{{Name: "got", Value: c.got}}
+}
+
+// BytesEquals returns a Checker checking that two byte slices are equal.
+//
+// On failure, both slices are shown as hex dumps around the first
+// difference, which is far more readable than the default formatting
+// when checking binary data.
+func BytesEquals(got, want []byte) Checker {
+	return &bytesEqualsChecker{
+		argPair: argPairOf(got, want),
+	}
+}
+
+type bytesEqualsChecker struct {
+	argPair[[]byte, []byte]
+}
+
+func (c *bytesEqualsChecker) Check(note func(key string, value any)) error {
+	if bytes.Equal(c.got, c.want) {
+		return nil
+	}
+	offset := firstDiff(c.got, c.want)
+	note("error", Unquoted("byte slices are not equal"))
+	note("first difference at offset", Unquoted(fmt.Sprintf("%d (%#x)", offset, offset)))
+	if len(c.got) != len(c.want) {
+		note("len(got)", len(c.got))
+		note("len(want)", len(c.want))
+	}
+	note("hex diff (-want +got)", Unquoted(hexDiff(c.got, c.want, offset)))
+	return ErrSilent
+}
+
+// ReaderEquals returns a Checker checking that the two given readers hold the
+// same contents. The readers are compared incrementally, so that large
+// streams are never read into memory as a whole.
+//
+// On failure, the offset of the first difference is reported, along with a
+// line diff of the surrounding contents if they are text, or a hex diff
+// otherwise.
+//
+// Note that both readers are consumed when the check is run, up to a little
+// past the first difference.
+func ReaderEquals(got, want io.Reader) Checker {
+	return &readerEqualsChecker{
+		argPair: argPairOf(got, want),
+	}
+}
+
+// readerChunkSize holds the number of bytes compared at a time by
+// ReaderEquals. It must be a multiple of hexDumpWidth.
+const readerChunkSize = 4096
+
+type readerEqualsChecker struct {
+	argPair[io.Reader, io.Reader]
+}
+
+func (c *readerEqualsChecker) Check(note func(key string, value any)) error {
+	if c.got == nil || c.want == nil {
+		return BadCheckf("nil reader provided")
+	}
+	gotBuf, wantBuf := make([]byte, readerChunkSize), make([]byte, readerChunkSize)
+	// Keep the end of the previous chunk around, so that the context before
+	// a difference can be shown.
+	var prev []byte
+	for pos := 0; ; pos += readerChunkSize {
+		gotN, err := readChunk(c.got, gotBuf)
+		if err != nil {
+			return fmt.Errorf("cannot read obtained contents: %v", err)
+		}
+		wantN, err := readChunk(c.want, wantBuf)
+		if err != nil {
+			return BadCheckf("cannot read expected contents: %v", err)
+		}
+		gotChunk, wantChunk := gotBuf[:gotN], wantBuf[:wantN]
+		if !bytes.Equal(gotChunk, wantChunk) {
+			base := pos - len(prev)
+			offset := len(prev) + firstDiff(gotChunk, wantChunk)
+			// Read a little more, so that the context after the difference
+			// can be shown too.
+			_, end := hexWindow(len(prev)+readerChunkSize*2, offset)
+			gotData := readWindow(c.got, append(append([]byte(nil), prev...), gotChunk...), end)
+			wantData := readWindow(c.want, append(append([]byte(nil), prev...), wantChunk...), end)
+			note("error", Unquoted("reader contents are not equal"))
+			note("first difference at offset", Unquoted(fmt.Sprintf("%d (%#x)", base+offset, base+offset)))
+			if utf8.Valid(gotData) && utf8.Valid(wantData) {
+				diff := cmp.Diff(strings.SplitAfter(string(wantData), "\n"), strings.SplitAfter(string(gotData), "\n"))
+				note("line diff (-want +got)", Unquoted(diff))
+			} else {
+				note("hex diff (-want +got)", Unquoted(hexDiffAt(gotData, wantData, base, offset)))
+			}
+			return ErrSilent
+		}
+		if gotN < readerChunkSize {
+			// Both readers are exhausted.
+			return nil
+		}
+		prev = append(prev[:0], gotChunk[readerChunkSize-hexDumpContext*hexDumpWidth:]...)
 	}
+}
 
-	gotType := reflect.TypeOf(c.got)
-	if !gotType.Implements(c.want) {
-		return fmt.Errorf("got value does not implement wanted interface")
+// readChunk reads len(buf) bytes from r into buf, or less if r is exhausted,
+// and returns the number of bytes read.
+func readChunk(r io.Reader, buf []byte) (int, error) {
+	n, err := io.ReadFull(r, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		err = nil
 	}
-
-	return nil
+	return n, err
 }
 
-func (c *implementsChecker) Args() []Arg {
-	return []Arg{{Name: "got", Value: c.got}, {Name: "want interface", Value: Unquoted(c.want.String())}}
-
+// readWindow returns data extended with bytes read from r, or truncated, so
+// that it holds n bytes, or less if r is exhausted.
+func readWindow(r io.Reader, data []byte, n int) []byte {
+	if len(data) >= n {
+		return data[:n]
+	}
+	buf := make([]byte, n-len(data))
+	// Read errors can be ignored, as the difference has already been found.
+	m, _ := io.ReadFull(r, buf)
+	return append(data, buf[:m]...)
 }
 
-// Satisfies returns a Checker checking that the provided value, when used as
-// argument of the provided predicate function, causes the function to return
-// true.
-func Satisfies[T any](got T, f func(T) bool) Checker {
-	return &satisfiesChecker[T]{
-		got:       got,
-		predicate: f,
+// BytesContains returns a Checker checking that the given byte slice
+// contains the given subslice.
+//
+// On failure, both slices are shown as hex dumps, truncated if long.
+func BytesContains(got, sub []byte) Checker {
+	return &bytesContainsChecker{
+		got: got,
+		sub: sub,
 	}
 }
 
-type satisfiesChecker[T any] struct {
-	got       T
-	predicate func(T) bool
+type bytesContainsChecker struct {
+	got, sub []byte
 }
 
-// Check implements Checker.Check by checking that args[0](got) == true.
-func (c *satisfiesChecker[T]) Check(note func(key string, value any)) error {
-	if c.predicate(c.got) {
+func (c *bytesContainsChecker) Check(note func(key string, value any)) error {
+	if bytes.Contains(c.got, c.sub) {
 		return nil
 	}
-	return fmt.Errorf("value does not satisfy predicate function")
+	note("error", Unquoted("no subslice match found"))
+	note("got", Unquoted(hexDump(c.got, 0)))
+	note("sub", Unquoted(hexDump(c.sub, 0)))
+	return ErrSilent
 }
 
-func (c *satisfiesChecker[T]) Args() []Arg {
+func (c *bytesContainsChecker) Args() []Arg {
 	return []Arg{{
 		Name:  "got",
 		Value: c.got,
 	}, {
-		Name:  "predicate",
-		Value: c.predicate,
+		Name:  "sub",
+		Value: c.sub,
 	}}
 }
 
-// IsTrue returns a Checker checking that the provided value is true.
-func IsTrue[T ~bool](got T) Checker {
-	return Equals(got, true)
+// FSEquals returns a Checker checking that the two given file systems hold
+// the same directories and files, with the same contents. On failure, the
+// first path, in lexical order, that is missing, unexpected or that has
+// different contents is reported, instead of the possibly large file systems.
+// Content differences are shown as a line diff for text files and as a hex
+// diff otherwise.
+//
+// This is useful for instance for checking the output of code generators
+// against a testing/fstest.MapFS.
+func FSEquals(got, want fs.FS) Checker {
+	return &fsEqualsChecker{
+		argPair: argPairOf(got, want),
+	}
 }
 
-// IsFalse returns a Checker checking that the provided value is false.
-func IsFalse[T ~bool](got T) Checker {
-	return Equals(got, false)
+type fsEqualsChecker struct {
+	argPair[fs.FS, fs.FS]
 }
 
-// Not returns a Checker negating the given Checker.
-func Not(c Checker) Checker {
-	// Not(Not(c)) becomes c.
-	if c, ok := c.(notChecker); ok {
-		return c.Checker
+func (c *fsEqualsChecker) Check(note func(key string, value any)) error {
+	if c.got == nil || c.want == nil {
+		return BadCheckf("nil file system provided")
 	}
-	return notChecker{
-		Checker: c,
+	wantEntries, err := fsEntries(c.want)
+	if err != nil {
+		return BadCheckf("cannot read expected file system: %v", err)
+	}
+	gotEntries, err := fsEntries(c.got)
+	if err != nil {
+		return fmt.Errorf("cannot read obtained file system: %v", err)
+	}
+	paths := make([]string, 0, len(wantEntries))
+	for path := range wantEntries {
+		paths = append(paths, path)
+	}
+	for path := range gotEntries {
+		if _, ok := wantEntries[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		// The file systems can be large, so only the path
+		// of the mismatch is reported instead of the arguments.
+		fail := func(msg string) error {
+			note("error", Unquoted(msg))
+			note("path", path)
+			return ErrSilent
+		}
+		gotIsDir, inGot := gotEntries[path]
+		wantIsDir, inWant := wantEntries[path]
+		switch {
+		case !inGot:
+			return fail("missing path")
+		case !inWant:
+			return fail("unexpected path")
+		case gotIsDir != wantIsDir && gotIsDir:
+			return fail("got directory but want file")
+		case gotIsDir != wantIsDir:
+			return fail("got file but want directory")
+		case gotIsDir:
+			continue
+		}
+		gotData, err := fs.ReadFile(c.got, path)
+		if err != nil {
+			return fmt.Errorf("cannot read obtained file: %v", err)
+		}
+		wantData, err := fs.ReadFile(c.want, path)
+		if err != nil {
+			return BadCheckf("cannot read expected file: %v", err)
+		}
+		if bytes.Equal(gotData, wantData) {
+			continue
+		}
+		err = fail("file contents differ")
+		if utf8.Valid(gotData) && utf8.Valid(wantData) {
+			diff := cmp.Diff(strings.SplitAfter(string(wantData), "\n"), strings.SplitAfter(string(gotData), "\n"))
+			note("line diff (-want +got)", Unquoted(diff))
+		} else {
+			note("hex diff (-want +got)", Unquoted(hexDiff(gotData, wantData, firstDiff(gotData, wantData))))
+		}
+		return err
 	}
+	return nil
 }
 
-type notChecker struct {
-	Checker
+// fsEntries returns all the paths in the given file system, excluding the
+// root, and whether each of them is a directory.
+func fsEntries(fsys fs.FS) (map[string]bool, error) {
+	entries := make(map[string]bool)
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != "." {
+			entries[path] = d.IsDir()
+		}
+		return nil
+	})
+	return entries, err
 }
 
-func (c notChecker) Check(note func(key string, value any)) error {
-	err := c.Checker.Check(note)
-	if IsBadCheck(err) {
-		return err
-	}
-	if err != nil {
-		return nil
-	}
-	if c, ok := c.Checker.(negatedError); ok {
-		return c.negatedError()
-	}
-	return errors.New("unexpected success")
+// SliceContains returns a Checker that succeeds if the given
+// slice contains the given element, by comparing for equality.
+func SliceContains[T any](container []T, elem T) Checker {
+	return SliceAny(container, F2(Equals[T], elem))
 }
 
-// StringContains returns a Checker checking that the given string contains the
-// given substring.
-func StringContains[T ~string](got, substr T) Checker {
-	return &stringContainsChecker[T]{
-		got:    got,
-		substr: substr,
+// MapContains returns a Checker that succeeds if the given value is
+// contained in the values of the given map, by comparing for equality.
+func MapContains[K comparable, V any](container map[K]V, elem V) Checker {
+	return MapAny(container, F2(Equals[V], elem))
+}
+
+// Contains returns a Checker that succeeds if the given container holds the
+// given element. The check depends on the kind of the container:
+//   - for strings, elem must be a string and is looked for as a substring;
+//   - for slices and arrays, elem is compared for equality with the elements;
+//   - for maps, elem is compared for equality with the values.
+//
+// Contains is useful when the type of the container is not statically known.
+// Otherwise, prefer StringContains, SliceContains or MapContains, which are
+// checked at compile time.
+func Contains[T any](container any, elem T) Checker {
+	return &containsChecker[T]{
+		container: container,
+		elem:      elem,
 	}
 }
 
-type stringContainsChecker[T ~string] struct {
-	got, substr T
+type containsChecker[T any] struct {
+	container any
+	elem      T
 }
 
-func (c *stringContainsChecker[T]) Check(note func(key string, value any)) error {
-	if strings.Contains(string(c.got), string(c.substr)) {
-		return nil
+func (c *containsChecker[T]) Check(note func(key string, value any)) error {
+	checker, err := c.checker()
+	if err != nil {
+		return err
 	}
-	return errors.New("no substring match found")
+	return checker.Check(note)
 }
 
-func (c *stringContainsChecker[T]) Args() []Arg {
+func (c *containsChecker[T]) Args() []Arg {
+	if checker, err := c.checker(); err == nil {
+		return checker.Args()
+	}
 	return []Arg{{
-		Name:  "got",
-		Value: c.got,
+		Name:  "container",
+		Value: c.container,
 	}, {
-		Name:  "substr",
-		Value: c.substr,
+		Name:  "want",
+		Value: c.elem,
 	}}
 }
 
-// SliceContains returns a Checker that succeeds if the given
-// slice contains the given element, by comparing for equality.
-func SliceContains[T any](container []T, elem T) Checker {
-	return SliceAny(container, F2(Equals[T], elem))
+// checker returns the specialized checker for the kind of the container.
+func (c *containsChecker[T]) checker() (Checker, error) {
+	v := reflect.ValueOf(c.container)
+	switch v.Kind() {
+	case reflect.String:
+		e := reflect.ValueOf(any(c.elem))
+		if e.Kind() != reflect.String {
+			return nil, BadCheckf("cannot look for element of type %T in a string", c.elem)
+		}
+		return StringContains(v.String(), e.String()), nil
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if err := checkElemType(v.Type().Elem(), typeOf[T]()); err != nil {
+			return nil, err
+		}
+		var newIter func() containerIter[any]
+		if v.Kind() == reflect.Map {
+			newIter = func() containerIter[any] {
+				return mapValueIter[any]{
+					iter: v.MapRange(),
+				}
+			}
+		} else {
+			elems := make([]any, v.Len())
+			for i := range elems {
+				elems[i] = v.Index(i).Interface()
+			}
+			newIter = func() containerIter[any] {
+				return newSliceIter(elems)
+			}
+		}
+		return &anyChecker[any]{
+			newIter:     newIter,
+			container:   c.container,
+			elemChecker: F2(Equals[any], any(c.elem)),
+		}, nil
+	case reflect.Invalid:
+		return nil, BadCheckf("cannot look for element in nil container")
+	}
+	return nil, BadCheckf("cannot look for element in container of type %T", c.container)
 }
 
-// MapContains returns a Checker that succeeds if the given value is
-// contained in the values of the given map, by comparing for equality.
-func MapContains[K comparable, V any](container map[K]V, elem V) Checker {
-	return MapAny(container, F2(Equals[V], elem))
+// checkElemType checks that values of type t can be compared with the elements
+// of a container of element type containerElem.
+func checkElemType(containerElem, t reflect.Type) error {
+	if t.AssignableTo(containerElem) || containerElem.AssignableTo(t) {
+		return nil
+	}
+	return BadCheckf("cannot look for element of type %s in container of element type %s", t, containerElem)
 }
 
 // SliceAny returns a Checker that uses the given checker to check elements
@@ -584,6 +3544,9 @@ func SliceAll[T any](container []T, f func(elem T) Checker) Checker {
 
 // MapAll returns a Checker that uses checkers returned by f to check values
 // of a map. It succeeds if f(v) passes the check for all values v in the map.
+// On failure it prints the error for the first key that failed, along with
+// the value at that key. Any diff reported by the element checker is
+// labeled with the key.
 func MapAll[K comparable, V any](container map[K]V, f func(elem V) Checker) Checker {
 	return &allChecker[V]{
 		newIter: func() containerIter[V] {
@@ -591,6 +3554,7 @@ func MapAll[K comparable, V any](container map[K]V, f func(elem V) Checker) Chec
 		},
 		container:   container,
 		elemChecker: f,
+		isMap:       true,
 	}
 }
 
@@ -598,56 +3562,344 @@ type allChecker[T any] struct {
 	newIter     func() containerIter[T]
 	container   any
 	elemChecker func(T) Checker
+	// isMap holds whether the container is a map, in which case the
+	// failing key is included in the notes of the element checker.
+	isMap bool
+}
+
+func (c *allChecker[T]) Check(notef func(key string, value any)) error {
+	for iter := c.newIter(); iter.next(); {
+		checker := c.elemChecker(iter.value())
+		notes, err := checkCollectingNotes(checker)
+		if err == nil {
+			continue
+		}
+		if IsBadCheck(err) {
+			return BadCheckf("at %s: %v", iter.key(), err)
+		}
+		notef("error", Unquoted("mismatch at "+iter.key()))
+		// TODO should we print the whole container value in
+		// verbose mode?
+		if err != ErrSilent {
+			// If the error's not silent, the checker is expecting
+			// the caller to print the error and the value that failed.
+			notef("error", Unquoted(err.Error()))
+			notef("first mismatched element", iter.value())
+		} else if c.isMap {
+			// The element checker may not report the value itself, and
+			// with maps the key alone is not enough to find it.
+			notef("first mismatched element", iter.value())
+		}
+		for _, n := range notes {
+			if c.isMap && strings.HasPrefix(n.key, "diff ") {
+				// For instance "diff (-want +got)" becomes
+				// `diff at key "b" (-want +got)`.
+				n.key = "diff at " + iter.key() + strings.TrimPrefix(n.key, "diff")
+			}
+			notef(n.key, n.value)
+		}
+		return ErrSilent
+	}
+	return nil
+}
+
+func (c *allChecker[T]) Args() []Arg {
+	// We haven't got an instance of the underlying checker,
+	// so just make one by passing the zero value. In general
+	// no checker should panic when being created regardless
+	// of the actual arguments, so that should be OK.
+	args := []Arg{{
+		Name:  "container",
+		Value: c.container,
+	}}
+	if eargs := c.elemChecker(*new(T)).Args(); len(eargs) > 0 {
+		args = append(args, eargs[1:]...)
+	}
+	return args
+}
+
+// checkCollectingNotes runs the given checker and returns the notes it added
+// along with its error, so that the caller can add its own notes at the start,
+// for instance to say which element failed.
+func checkCollectingNotes(checker Checker) ([]note, error) {
+	var notes []note
+	err := checker.Check(func(key string, value any) {
+		notes = append(notes, note{key, value})
+	})
+	return notes, err
+}
+
+// SliceCountMatching returns a Checker that uses checkers returned by f to
+// check elements of a slice. It succeeds if at least atLeast elements of the
+// slice pass the check. On failure it prints the number of elements that
+// passed.
+func SliceCountMatching[T any](got []T, f func(elem T) Checker, atLeast int) Checker {
+	return &sliceCountMatchingChecker[T]{
+		got:         got,
+		elemChecker: f,
+		atLeast:     atLeast,
+	}
+}
+
+type sliceCountMatchingChecker[T any] struct {
+	got         []T
+	elemChecker func(T) Checker
+	atLeast     int
+}
+
+func (c *sliceCountMatchingChecker[T]) Check(note func(key string, value any)) error {
+	if c.atLeast < 0 {
+		return BadCheckf("negative count %d", c.atLeast)
+	}
+	n := 0
+	for i, elem := range c.got {
+		err := c.elemChecker(elem).Check(func(key string, value any) {})
+		if err == nil {
+			n++
+			continue
+		}
+		if IsBadCheck(err) {
+			return BadCheckf("at index %d: %v", i, err)
+		}
+	}
+	if n >= c.atLeast {
+		return nil
+	}
+	note("matching elements", n)
+	return errors.New("too few matching elements")
+}
+
+func (c *sliceCountMatchingChecker[T]) Args() []Arg {
+	// As with anyChecker, make an instance of the underlying checker
+	// using the zero value in order to retrieve its arguments.
+	args := []Arg{{
+		Name:  "container",
+		Value: c.got,
+	}}
+	if eargs := c.elemChecker(*new(T)).Args(); len(eargs) > 0 {
+		args = append(args, eargs[1:]...)
+	}
+	return append(args, Arg{Name: "at least", Value: c.atLeast})
+}
+
+// SliceMatches returns a Checker that checks the elements of a slice
+// positionally: it succeeds if the slice has exactly one element for each
+// function in checkers, and checkers[i](got[i]) passes the check for every
+// index i. On failure it prints the error from the first index that failed.
+//
+// This is useful for checking slices with a fixed shape, where each element
+// has different expectations. See SliceAll for applying the same check to all
+// elements.
+func SliceMatches[T any](got []T, checkers []func(elem T) Checker) Checker {
+	return &sliceMatchesChecker[T]{
+		got:      got,
+		checkers: checkers,
+	}
+}
+
+type sliceMatchesChecker[T any] struct {
+	got      []T
+	checkers []func(elem T) Checker
+}
+
+func (c *sliceMatchesChecker[T]) Check(notef func(key string, value any)) error {
+	for i, f := range c.checkers {
+		if f == nil {
+			return BadCheckf("at index %d: nil checker function provided", i)
+		}
+	}
+	if len(c.got) != len(c.checkers) {
+		notef("len(got)", len(c.got))
+		notef("len(checkers)", len(c.checkers))
+		return errors.New("slice length does not match the number of checkers")
+	}
+	for i, elem := range c.got {
+		checker := c.checkers[i](elem)
+		notes, err := checkCollectingNotes(checker)
+		if err == nil {
+			continue
+		}
+		if IsBadCheck(err) {
+			return BadCheckf("at index %d: %v", i, err)
+		}
+		notef("error", Unquoted(fmt.Sprintf("mismatch at index %d", i)))
+		if err != ErrSilent {
+			// If the error's not silent, the checker is expecting
+			// the caller to print the error and its arguments.
+			notef("error", Unquoted(err.Error()))
+			notef("mismatched element", elem)
+		}
+		for _, n := range notes {
+			notef(n.key, n.value)
+		}
+		if err != ErrSilent {
+			if args := checker.Args(); len(args) > 0 {
+				for _, arg := range args[1:] {
+					notef(arg.Name, arg.Value)
+				}
+			}
+		}
+		return ErrSilent
+	}
+	return nil
+}
+
+func (c *sliceMatchesChecker[T]) Args() []Arg {
+	return []Arg{{Name: "got", Value: c.got}}
+}
+
+// Repeatedly returns a Checker that calls f n times, running the checker it
+// returns each time. It succeeds if all the checkers pass, and fails on the
+// first iteration that fails, reporting the iteration number along with the
+// failure of the checker.
+//
+// This is useful for checking that repeated calls behave consistently, for
+// instance that a memoized function keeps returning the same result.
+func Repeatedly(n int, f func() Checker) Checker {
+	return &repeatedlyChecker{
+		n: n,
+		f: f,
+	}
+}
+
+type repeatedlyChecker struct {
+	n int
+	f func() Checker
 }
 
-func (c *allChecker[T]) Check(notef func(key string, value any)) error {
-	for iter := c.newIter(); iter.next(); {
-		// Store any notes added by the checker so
-		// we can add our own note at the start
-		// to say which element failed.
-		var notes []note
-		checker := c.elemChecker(iter.value())
-		err := checker.Check(
-			func(key string, val any) {
-				notes = append(notes, note{key, val})
-			},
-		)
+func (c *repeatedlyChecker) Check(notef func(key string, value any)) error {
+	if c.n < 0 {
+		return BadCheckf("invalid number of iterations %d", c.n)
+	}
+	if c.f == nil {
+		return BadCheckf("nil checker function provided")
+	}
+	for i := 1; i <= c.n; i++ {
+		checker := c.f()
+		if checker == nil {
+			return BadCheckf("at iteration %d: nil checker provided", i)
+		}
+		notes, err := checkCollectingNotes(checker)
 		if err == nil {
 			continue
 		}
 		if IsBadCheck(err) {
-			return BadCheckf("at %s: %v", iter.key(), err)
+			return BadCheckf("at iteration %d: %v", i, err)
 		}
-		notef("error", Unquoted("mismatch at "+iter.key()))
-		// TODO should we print the whole container value in
-		// verbose mode?
+		notef("error", Unquoted(fmt.Sprintf("failure at iteration %d of %d", i, c.n)))
 		if err != ErrSilent {
-			// If the error's not silent, the checker is expecting
-			// the caller to print the error and the value that failed.
 			notef("error", Unquoted(err.Error()))
-			notef("first mismatched element", iter.value())
 		}
 		for _, n := range notes {
 			notef(n.key, n.value)
 		}
+		if err != ErrSilent {
+			for _, arg := range checker.Args() {
+				notef(arg.Name, arg.Value)
+			}
+		}
 		return ErrSilent
 	}
 	return nil
 }
 
-func (c *allChecker[T]) Args() []Arg {
-	// We haven't got an instance of the underlying checker,
-	// so just make one by passing the zero value. In general
-	// no checker should panic when being created regardless
-	// of the actual arguments, so that should be OK.
-	args := []Arg{{
-		Name:  "container",
-		Value: c.container,
-	}}
-	if eargs := c.elemChecker(*new(T)).Args(); len(eargs) > 0 {
-		args = append(args, eargs[1:]...)
+func (c *repeatedlyChecker) Args() []Arg {
+	return []Arg{{Name: "iterations", Value: c.n}}
+}
+
+// Deterministic returns a Checker that calls f the given number of times and
+// checks that all the results are deep equal to the result of the first
+// call, as in DeepEquals. On failure, the number of the first diverging call
+// is reported along with the diff from the first result.
+//
+// This is useful for catching accidental nondeterminism, for instance map
+// iteration order leaking into the output of a function.
+func Deterministic[T any](f func() T, times int) Checker {
+	return &deterministicChecker[T]{
+		f:     f,
+		times: times,
+	}
+}
+
+type deterministicChecker[T any] struct {
+	f     func() T
+	times int
+}
+
+func (c *deterministicChecker[T]) Check(notef func(key string, value any)) error {
+	if c.times < 1 {
+		return BadCheckf("invalid number of calls %d", c.times)
+	}
+	if c.f == nil {
+		return BadCheckf("nil function provided")
+	}
+	first := c.f()
+	for i := 2; i <= c.times; i++ {
+		relay := relayDeepEqualsNotes(notef, fmt.Sprintf("call %d returned a different result from call 1", i), fmt.Sprintf("call %d", i), "call 1")
+		if err := DeepEquals(c.f(), first).Check(relay); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *deterministicChecker[T]) Args() []Arg {
+	return []Arg{{Name: "calls", Value: c.times}}
+}
+
+// AlwaysWithin returns a Checker that repeatedly calls sample for the given
+// duration, checking that every sampled value is within [min, max]. It fails
+// as soon as a value out of bounds is observed, reporting that value, the
+// sample number and the time elapsed since the start of the check.
+//
+// This is useful for checking invariants while other goroutines mutate the
+// sampled state, for instance to test that a counter protected by a lock
+// never goes out of range. Note that the check blocks for the whole duration
+// when it succeeds.
+func AlwaysWithin[T ordered](sample func() T, min, max T, duration time.Duration) Checker {
+	return &alwaysWithinChecker[T]{
+		sample:   sample,
+		min:      min,
+		max:      max,
+		duration: duration,
+	}
+}
+
+type alwaysWithinChecker[T ordered] struct {
+	sample   func() T
+	min, max T
+	duration time.Duration
+}
+
+func (c *alwaysWithinChecker[T]) Check(note func(key string, value any)) error {
+	if c.sample == nil {
+		return BadCheckf("nil sample function provided")
+	}
+	if c.max < c.min {
+		return BadCheckf("invalid bounds: max is less than min")
+	}
+	if c.duration < 0 {
+		return BadCheckf("negative duration %v", c.duration)
+	}
+	start := timeNow()
+	for n := 1; ; n++ {
+		v := c.sample()
+		if v < c.min || v > c.max {
+			note("sample", v)
+			note("sample number", n)
+			note("elapsed", timeNow().Sub(start))
+			return errors.New("sampled value out of bounds")
+		}
+		if timeNow().Sub(start) >= c.duration {
+			return nil
+		}
+		// Give concurrent mutators a chance to run.
+		runtime.Gosched()
 	}
-	return args
+}
+
+func (c *alwaysWithinChecker[T]) Args() []Arg {
+	return []Arg{{Name: "min", Value: c.min}, {Name: "max", Value: c.max}, {Name: "duration", Value: c.duration}}
 }
 
 // JSONEquals returns a Checker that checks whether a string or byte slice is
@@ -655,8 +3907,102 @@ func (c *allChecker[T]) Args() []Arg {
 //
 // It uses DeepEquals to do the comparison. If a more sophisticated comparison
 // is required, use CodecEquals directly.
+//
+// On failure, both values are rendered as indented JSON and a line-based diff
+// of the two documents is reported.
 func JSONEquals[T []byte | string](got T, want any) Checker {
-	return CodecEquals(got, want, json.Marshal, json.Unmarshal)
+	return &codecEqualChecker[T]{
+		argPair:   argPairOf(got, want),
+		marshal:   json.Marshal,
+		unmarshal: json.Unmarshal,
+		indent: func(v any) ([]byte, error) {
+			return json.MarshalIndent(v, "", "  ")
+		},
+	}
+}
+
+// JSONEqualsUnordered is like JSONEquals, except that the order of the
+// elements in JSON arrays is ignored, at any depth. This is useful when an API
+// returns arrays in an unspecified order.
+//
+// On failure, both values are rendered as indented JSON, with the elements
+// of all arrays sorted, and a line-based diff of the two documents is
+// reported.
+func JSONEqualsUnordered[T []byte | string](got T, want any) Checker {
+	return &codecEqualChecker[T]{
+		argPair:   argPairOf(got, want),
+		marshal:   json.Marshal,
+		unmarshal: json.Unmarshal,
+		indent: func(v any) ([]byte, error) {
+			return json.MarshalIndent(v, "", "  ")
+		},
+		normalize: sortJSONArrays,
+	}
+}
+
+// sortJSONArrays returns the given unmarshaled JSON value with the elements
+// of all its arrays sorted by their JSON encoding.
+func sortJSONArrays(v any) any {
+	switch v := v.(type) {
+	case []any:
+		type elem struct {
+			value any
+			key   string
+		}
+		elems := make([]elem, len(v))
+		for i, e := range v {
+			e = sortJSONArrays(e)
+			// Marshaling cannot fail, as the value has been unmarshaled from JSON.
+			data, _ := json.Marshal(e)
+			elems[i] = elem{value: e, key: string(data)}
+		}
+		sort.SliceStable(elems, func(i, j int) bool {
+			return elems[i].key < elems[j].key
+		})
+		sorted := make([]any, len(elems))
+		for i, e := range elems {
+			sorted[i] = e.value
+		}
+		return sorted
+	case map[string]any:
+		sorted := make(map[string]any, len(v))
+		for k, e := range v {
+			sorted[k] = sortJSONArrays(e)
+		}
+		return sorted
+	}
+	return v
+}
+
+// IsValidJSON returns a Checker checking that the given string or byte slice
+// holds syntactically valid JSON. On failure, the offset at which parsing
+// failed is reported along with a hex dump of the surrounding bytes.
+func IsValidJSON[T []byte | string](got T) Checker {
+	return &isValidJSONChecker[T]{
+		got: got,
+	}
+}
+
+type isValidJSONChecker[T []byte | string] struct {
+	got T
+}
+
+func (c *isValidJSONChecker[T]) Check(note func(key string, value any)) error {
+	data := []byte(c.got)
+	if json.Valid(data) {
+		return nil
+	}
+	var v any
+	err := json.Unmarshal(data, &v)
+	if err, ok := err.(*json.SyntaxError); ok {
+		note("offset", err.Offset)
+		note("context", Unquoted(hexDump(data, int(err.Offset))))
+	}
+	return fmt.Errorf("invalid JSON: %v", err)
+}
+
+func (c *isValidJSONChecker[T]) Args() []Arg {
+	return []Arg{{Name: "got", Value: c.got}}
 }
 
 // CodecEquals returns a Checker that checks for codec value equivalence.
@@ -692,25 +4038,173 @@ type codecEqualChecker[T []byte | string] struct {
 	marshal   func(any) ([]byte, error)
 	unmarshal func([]byte, any) error
 	opts      []cmp.Option
+	// indent, if not nil, is used to render the unmarshaled values as text
+	// when reporting failures.
+	indent func(any) ([]byte, error)
+	// normalize, if not nil, is applied to the unmarshaled values before
+	// comparing them.
+	normalize func(any) any
 }
 
 func (c *codecEqualChecker[T]) Check(note func(key string, value any)) error {
-	wantContentBytes, err := c.marshal(c.want)
+	wantContentVal, err := codecValue(c.want, c.marshal, c.unmarshal)
 	if err != nil {
-		return BadCheckf("cannot marshal expected contents: %v", err)
-	}
-	var wantContentVal any
-	if err := c.unmarshal(wantContentBytes, &wantContentVal); err != nil {
-		return BadCheckf("cannot unmarshal expected contents: %v", err)
+		return err
 	}
 	var gotContentVal any
 	if err := c.unmarshal([]byte(c.got), &gotContentVal); err != nil {
 		return fmt.Errorf("cannot unmarshal obtained contents: %v; %q", err, c.got)
 	}
+	if c.normalize != nil {
+		gotContentVal, wantContentVal = c.normalize(gotContentVal), c.normalize(wantContentVal)
+	}
+	cmpEq := CmpEquals(gotContentVal, wantContentVal, c.opts...).(*cmpEqualsChecker[any])
+	if c.indent == nil {
+		return cmpEq.Check(note)
+	}
+	// Still compare the values structurally, but report them as text.
+	if err := cmpEq.Check(func(key string, value any) {}); err != ErrSilent {
+		return err
+	}
+	gotText, gotErr := c.indent(gotContentVal)
+	wantText, wantErr := c.indent(wantContentVal)
+	if gotErr != nil || wantErr != nil {
+		return cmpEq.Check(note)
+	}
+	note("error", Unquoted("values are not deep equal"))
+	diff := cmp.Diff(strings.SplitAfter(string(wantText), "\n"), strings.SplitAfter(string(gotText), "\n"))
+	note("diff (-want +got)", Unquoted(diff))
+	note("got", SuppressedIfLong{Unquoted(gotText)})
+	note("want", SuppressedIfLong{Unquoted(wantText)})
+	return ErrSilent
+}
+
+// CodecEqualsReader is like CodecEquals except that the obtained contents are
+// decoded from the given reader using decode, without being read into memory
+// first. This is useful for instance for checking the body of an HTTP
+// response. The expected value is marshaled using marshal and then decoded
+// using decode too.
+//
+// Note that the reader is consumed when the check is run.
+func CodecEqualsReader(
+	got io.Reader,
+	want any,
+	marshal func(any) ([]byte, error),
+	decode func(io.Reader, any) error,
+	opts ...cmp.Option,
+) Checker {
+	return &codecEqualsReaderChecker{
+		argPair: argPairOf(got, want),
+		marshal: marshal,
+		decode:  decode,
+		opts:    opts,
+	}
+}
+
+type codecEqualsReaderChecker struct {
+	argPair[io.Reader, any]
+	marshal func(any) ([]byte, error)
+	decode  func(io.Reader, any) error
+	opts    []cmp.Option
+}
+
+func (c *codecEqualsReaderChecker) Check(note func(key string, value any)) error {
+	if c.got == nil {
+		return BadCheckf("nil reader provided")
+	}
+	unmarshal := func(data []byte, v any) error {
+		return c.decode(bytes.NewReader(data), v)
+	}
+	wantContentVal, err := codecValue(c.want, c.marshal, unmarshal)
+	if err != nil {
+		return err
+	}
+	var gotContentVal any
+	if err := c.decode(c.got, &gotContentVal); err != nil {
+		return fmt.Errorf("cannot decode obtained contents: %v", err)
+	}
 	cmpEq := CmpEquals(gotContentVal, wantContentVal, c.opts...).(*cmpEqualsChecker[any])
 	return cmpEq.Check(note)
 }
 
+// codecValue marshals the given value and unmarshals the result into an any
+// value, so that it can be compared with obtained contents.
+func codecValue(v any, marshal func(any) ([]byte, error), unmarshal func([]byte, any) error) (any, error) {
+	data, err := marshal(v)
+	if err != nil {
+		return nil, BadCheckf("cannot marshal expected contents: %v", err)
+	}
+	var val any
+	if err := unmarshal(data, &val); err != nil {
+		return nil, BadCheckf("cannot unmarshal expected contents: %v", err)
+	}
+	return val, nil
+}
+
+// RoundTrips returns a Checker checking that the provided value is unchanged
+// after being marshaled with marshal and unmarshaled into a new value of the
+// same type with unmarshal. The values are compared as with DeepEquals, and
+// on failure a diff between the original and the round-tripped value is
+// reported along with the encoded data.
+//
+// See JSONRoundTrips for an example of this in use.
+func RoundTrips[T any](got T, marshal func(T) ([]byte, error), unmarshal func([]byte, *T) error) Checker {
+	return &roundTripsChecker[T]{
+		got:       got,
+		marshal:   marshal,
+		unmarshal: unmarshal,
+	}
+}
+
+// JSONRoundTrips returns a Checker checking that the provided value is
+// unchanged after being marshaled to JSON and unmarshaled back. It is a
+// shorthand for RoundTrips using json.Marshal and json.Unmarshal.
+//
+// For instance:
+//
+//	qt.Assert(t, qt.JSONRoundTrips(config))
+func JSONRoundTrips[T any](got T) Checker {
+	return RoundTrips(got, func(v T) ([]byte, error) {
+		return json.Marshal(v)
+	}, func(data []byte, v *T) error {
+		return json.Unmarshal(data, v)
+	})
+}
+
+type roundTripsChecker[T any] struct {
+	got       T
+	marshal   func(T) ([]byte, error)
+	unmarshal func([]byte, *T) error
+}
+
+func (c *roundTripsChecker[T]) Check(notef func(key string, value any)) error {
+	if c.marshal == nil {
+		return BadCheckf("nil marshal function provided")
+	}
+	if c.unmarshal == nil {
+		return BadCheckf("nil unmarshal function provided")
+	}
+	data, err := c.marshal(c.got)
+	if err != nil {
+		return BadCheckf("cannot marshal value: %v", err)
+	}
+	var roundTripped T
+	if err := c.unmarshal(data, &roundTripped); err != nil {
+		notef("encoded", SuppressedIfLong{string(data)})
+		return BadCheckf("cannot unmarshal encoded value: %v", err)
+	}
+	cmpEq := CmpEquals(roundTripped, c.got, registeredCmpOptions()...)
+	if err := cmpEq.Check(relayDeepEqualsNotes(notef, "value changed after round trip", "round-tripped", "original")); err != ErrSilent {
+		return err
+	}
+	notef("encoded", SuppressedIfLong{string(data)})
+	return ErrSilent
+}
+
+func (c *roundTripsChecker[T]) Args() []Arg {
+	return []Arg{{Name: "got", Value: c.got}}
+}
+
 // ErrorAs retruns a Checker checking that the error is or wraps a specific
 // error type. If so, it assigns it to the provided pointer. This is analogous
 // to calling errors.As.
@@ -757,6 +4251,15 @@ func (c *errorAsChecker[T]) Args() []Arg {
 	}}
 }
 
+// HasErrorType returns a Checker checking that the error is or wraps an
+// error of type T. It is like ErrorAs, but for when the error does not need to
+// be captured, so that no target pointer must be provided:
+//
+//	qt.Assert(t, qt.HasErrorType[*os.PathError](err))
+func HasErrorType[T any](got error) Checker {
+	return ErrorAs[T](got, nil)
+}
+
 // ErrorIs returns a Checker that checks that the error is or wraps a specific
 // error value. This is analogous to calling errors.Is.
 func ErrorIs(got, want error) Checker {
@@ -779,15 +4282,199 @@ func (c *errorIsChecker) Check(note func(key string, value any)) error {
 	return nil
 }
 
+// ErrorEquals returns a Checker that checks that two errors are equivalent,
+// that is, that both errors.Is(got, want) and errors.Is(want, got) are true.
+// This is stricter than ErrorIs, which also succeeds when got merely wraps
+// want: for instance, an error returned by fmt.Errorf("context: %w", want)
+// passes ErrorIs but fails ErrorEquals. Two nil errors are considered equal.
+func ErrorEquals(got, want error) Checker {
+	return &errorEqualsChecker{
+		argPair: argPairOf(got, want),
+	}
+}
+
+type errorEqualsChecker struct {
+	argPair[error, error]
+}
+
+func (c *errorEqualsChecker) Check(note func(key string, value any)) error {
+	switch {
+	case c.got == nil && c.want == nil:
+		return nil
+	case c.got == nil:
+		return errors.New("got nil error but want non-nil")
+	case c.want == nil:
+		return errors.New("got non-nil error but want nil")
+	case !errors.Is(c.got, c.want):
+		return errors.New("wanted error is not found in error chain")
+	case !errors.Is(c.want, c.got):
+		return errors.New("got error wraps wanted error but is not equivalent to it")
+	}
+	return nil
+}
+
+// IsContextCanceled returns a Checker that checks that the error is or wraps
+// context.Canceled. It is a shorthand for ErrorIs(got, context.Canceled),
+// with a more descriptive failure message.
+func IsContextCanceled(got error) Checker {
+	return &contextErrorChecker{
+		argPair: argPairOf(got, context.Canceled),
+		what:    "context cancellation",
+	}
+}
+
+// IsContextDeadlineExceeded returns a Checker that checks that the error is
+// or wraps context.DeadlineExceeded. It is a shorthand for
+// ErrorIs(got, context.DeadlineExceeded), with a more descriptive failure
+// message.
+func IsContextDeadlineExceeded(got error) Checker {
+	return &contextErrorChecker{
+		argPair: argPairOf(got, context.DeadlineExceeded),
+		what:    "context deadline exceeded",
+	}
+}
+
+type contextErrorChecker struct {
+	argPair[error, error]
+	what string
+}
+
+func (c *contextErrorChecker) Check(note func(key string, value any)) error {
+	if c.got == nil {
+		return fmt.Errorf("expected %s but got nil error", c.what)
+	}
+	if !errors.Is(c.got, c.want) {
+		return fmt.Errorf("expected %s but got another error", c.what)
+	}
+	return nil
+}
+
+func (c *contextErrorChecker) negatedError() error {
+	return fmt.Errorf("unexpected %s", c.what)
+}
+
+// ContextDone returns a Checker checking that the provided context is done,
+// that is, that its Done channel is closed. On failure of the negated
+// checker, the context error is reported.
+//
+// Note that this is a point-in-time check: it does not wait for the context
+// to be done.
+func ContextDone(ctx context.Context) Checker {
+	return &contextDoneChecker{
+		got:  ctx,
+		want: true,
+	}
+}
+
+// ContextNotDone returns a Checker checking that the provided context is not
+// done yet. On failure, the context error is reported.
+//
+// Like ContextDone, this is a point-in-time check.
+func ContextNotDone(ctx context.Context) Checker {
+	return &contextDoneChecker{
+		got:  ctx,
+		want: false,
+	}
+}
+
+type contextDoneChecker struct {
+	got  context.Context
+	want bool
+}
+
+func (c *contextDoneChecker) Check(note func(key string, value any)) error {
+	if c.got == nil {
+		return BadCheckf("nil context provided")
+	}
+	var done bool
+	select {
+	case <-c.got.Done():
+		done = true
+		note("context error", c.got.Err())
+	default:
+	}
+	switch {
+	case done == c.want:
+		return nil
+	case done:
+		return errors.New("context is done")
+	default:
+		return errors.New("context is not done yet")
+	}
+}
+
+func (c *contextDoneChecker) Args() []Arg {
+	return []Arg{{Name: "got", Value: c.got}}
+}
+
+// WaitGroupDone returns a Checker checking that the provided WaitGroup
+// completes, that is, that its Wait method returns, within the given timeout.
+//
+// When the timeout expires, the goroutine waiting on the group keeps running
+// until the group completes.
+func WaitGroupDone(wg *sync.WaitGroup, timeout time.Duration) Checker {
+	return &waitGroupDoneChecker{
+		wg:      wg,
+		timeout: timeout,
+	}
+}
+
+type waitGroupDoneChecker struct {
+	wg      *sync.WaitGroup
+	timeout time.Duration
+}
+
+func (c *waitGroupDoneChecker) Check(note func(key string, value any)) error {
+	if c.wg == nil {
+		return BadCheckf("nil WaitGroup provided")
+	}
+	if c.timeout < 0 {
+		return BadCheckf("negative timeout %v", c.timeout)
+	}
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+	timer := time.NewTimer(c.timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("WaitGroup did not complete within %v", c.timeout)
+	}
+}
+
+func (c *waitGroupDoneChecker) Args() []Arg {
+	return []Arg{{Name: "timeout", Value: c.timeout}}
+}
+
+// AnchorPatterns reports whether regular expression patterns provided as
+// strings to Matches, ErrorMatches, PanicMatches and similar checkers are
+// anchored, so that they must match the whole string. It is true by default.
+//
+// Setting it to false makes those patterns match any part of the string, as
+// with MatchesPart. This provides a migration path for code bases whose
+// patterns assume substring matching. The variable is consulted when the
+// checker is created.
+var AnchorPatterns = true
+
 type matcher = func(got string, msg string, note func(key string, value any)) error
 
 // newMatcher returns a matcher function that can be used by checkers when
 // checking that a string or an error matches the provided StringOrRegexp.
-func newMatcher[StringOrRegexp string | *regexp.Regexp](regex StringOrRegexp) matcher {
+// If anchor is true, a pattern provided as a string must match the whole
+// string.
+func newMatcher[StringOrRegexp string | *regexp.Regexp](regex StringOrRegexp, anchor bool) matcher {
 	var re *regexp.Regexp
 	switch r := any(regex).(type) {
 	case string:
-		re0, err := regexp.Compile("^(" + r + ")$")
+		pattern := r
+		if anchor && AnchorPatterns {
+			pattern = "^(" + r + ")$"
+		}
+		re0, err := regexp.Compile(pattern)
 		if err != nil {
 			return func(got string, msg string, note func(key string, value any)) error {
 				note("regexp", r)
@@ -834,6 +4521,20 @@ func canBeNil(k reflect.Kind) bool {
 	return false
 }
 
+// uncomparableType returns the dynamic type of the given values that is
+// responsible for a panic when comparing them.
+func uncomparableType(got, want any) reflect.Type {
+	if t := reflect.TypeOf(got); t != nil && !t.Comparable() {
+		return t
+	}
+	if t := reflect.TypeOf(want); t != nil && !t.Comparable() {
+		return t
+	}
+	// The types are comparable, but contain uncomparable values,
+	// for instance in an interface field.
+	return reflect.TypeOf(got)
+}
+
 func typeOf[T any]() reflect.Type {
 	return reflect.TypeOf((*T)(nil)).Elem()
 }
@@ -843,6 +4544,38 @@ func valueAs[T any](v reflect.Value) (r T) {
 	return
 }
 
+// ordered is satisfied by types supporting the < operator.
+type ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
+
+// integer is satisfied by integer types.
+type integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// float is satisfied by floating point types.
+type float interface {
+	~float32 | ~float64
+}
+
+// number is satisfied by integer and floating point types.
+type number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// signed is satisfied by signed integer and floating point types.
+type signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~float32 | ~float64
+}
+
 // F2 factors a 2-argument checker function into a single argument function suitable
 // for passing to an *Any or *All checker. Whenever the returned function is called,
 // cf is called with arguments (got, want).
@@ -851,3 +4584,29 @@ func F2[Got, Want any](cf func(got Got, want Want) Checker, want Want) func(got
 		return cf(got, want)
 	}
 }
+
+// NewChecker returns a Checker that runs the given check function and
+// reports the given arguments on failure. It is useful for one-off checks
+// that do not warrant declaring a new Checker type.
+func NewChecker(args []Arg, check func(note func(key string, value any)) error) Checker {
+	return &funcChecker{
+		args:  args,
+		check: check,
+	}
+}
+
+type funcChecker struct {
+	args  []Arg
+	check func(note func(key string, value any)) error
+}
+
+func (c *funcChecker) Check(note func(key string, value any)) error {
+	if c.check == nil {
+		return BadCheckf("nil check function provided")
+	}
+	return c.check(note)
+}
+
+func (c *funcChecker) Args() []Arg {
+	return c.args
+}
@@ -3,12 +3,22 @@
 package qt_test
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
+	"testing/iotest"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
@@ -40,6 +50,26 @@ type Fooer interface {
 	Foo()
 }
 
+// fooBarer is an interface with several methods for testing.
+type fooBarer interface {
+	Foo()
+	Bar(int) string
+	Baz()
+}
+
+// partialFooBarer only partially implements fooBarer.
+type partialFooBarer struct{}
+
+func (partialFooBarer) Bar(string) string { return "" }
+
+func (*partialFooBarer) Baz() {}
+
+// roundTripType loses information when round-tripped through JSON.
+type roundTripType struct {
+	Name     string
+	Internal int `json:"-"`
+}
+
 type cmpType struct {
 	Strings []any
 	Ints    []int
@@ -56,11 +86,30 @@ type OuterJSON struct {
 	Second []*InnerJSON `json:"Last,omitempty" yaml:"last,omitempty"`
 }
 
+type recordMeta struct {
+	Owner   string
+	Created int
+}
+
+type record struct {
+	ID   int
+	Name string
+	Meta recordMeta
+}
+
 type boolean bool
 
 var (
 	targetErr = &errTarget{msg: "target"}
 
+	outerJSON = &OuterJSON{
+		First: 47.11,
+		Second: []*InnerJSON{
+			{First: "hello", Second: 42, Third: map[string]bool{"ok": true}},
+			nil,
+		},
+	}
+
 	goTime = time.Date(2012, 3, 28, 0, 0, 0, 0, time.UTC)
 	chInt  = func() chan int {
 		ch := make(chan int, 4)
@@ -220,7 +269,7 @@ want:
 error:
   got non-nil error
 got:
-  e<nil>
+  e(*qt_test.errTest)(nil)
 want:
   nil
 `,
@@ -311,14 +360,57 @@ want:
 	}),
 	expectedCheckFailure: `
 error:
-  runtime error: comparing uncomparable type struct { Ints []int }
+  bad check: cannot compare values of type struct { Ints []int }: they are not comparable; use qt.DeepEquals instead
 got:
   struct { Ints []int }{
       Ints: {42, 47},
   }
 want:
   <same as "got">
-`}, {
+`,
+	expectedNegateFailure: `
+error:
+  bad check: cannot compare values of type struct { Ints []int }: they are not comparable; use qt.DeepEquals instead
+got:
+  struct { Ints []int }{
+      Ints: {42, 47},
+  }
+want:
+  <same as "got">
+`,
+}, {
+	about: "Equals: uncomparable type in want",
+	checker: qt.Equals[any](42, map[string]int{
+		"a": 1,
+	}),
+	expectedCheckFailure: `
+error:
+  values are not equal
+got:
+  int(42)
+want:
+  map[string]int{"a":1}
+`,
+}, {
+	about:   "Equals: uncomparable types on both sides",
+	checker: qt.Equals[any](map[string]int{}, map[string]int{}),
+	expectedCheckFailure: `
+error:
+  bad check: cannot compare values of type map[string]int: they are not comparable; use qt.DeepEquals instead
+got:
+  map[string]int{}
+want:
+  <same as "got">
+`,
+	expectedNegateFailure: `
+error:
+  bad check: cannot compare values of type map[string]int: they are not comparable; use qt.DeepEquals instead
+got:
+  map[string]int{}
+want:
+  <same as "got">
+`,
+}, {
 	about:   "DeepEquals: same values",
 	checker: qt.DeepEquals(cmpEqualsGot, cmpEqualsGot),
 	expectedNegateFailure: `
@@ -418,6 +510,67 @@ want:
       int(42),
   }
 `, diff([]any{cmpEqualsWant, cmpEqualsWant}, []any{cmpEqualsWant, cmpEqualsWant, 42})),
+}, {
+	about:   "CmpEquals: report first difference",
+	checker: qt.CmpEquals(map[string]record{"r": {ID: 1, Meta: recordMeta{Owner: "bob", Created: 2}}}, map[string]record{"r": {ID: 1, Meta: recordMeta{Owner: "alice", Created: 1}}}, qt.ReportFirstDifference),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  values are not deep equal
+first difference at:
+  root["r"].Meta.Owner
+diff (-want +got):
+%s
+got:
+  map[string]qt_test.record{
+      "r": {
+          ID:   1,
+          Name: "",
+          Meta: qt_test.recordMeta{Owner:"bob", Created:2},
+      },
+  }
+want:
+  map[string]qt_test.record{
+      "r": {
+          ID:   1,
+          Name: "",
+          Meta: qt_test.recordMeta{Owner:"alice", Created:1},
+      },
+  }
+`, diff(map[string]record{"r": {ID: 1, Meta: recordMeta{Owner: "bob", Created: 2}}}, map[string]record{"r": {ID: 1, Meta: recordMeta{Owner: "alice", Created: 1}}})),
+}, {
+	about:   "CmpEquals: report Go source",
+	checker: qt.CmpEquals([]record{{ID: 1, Meta: recordMeta{Owner: "bob"}}}, []record{{ID: 1}}, qt.ReportGoSource),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  values are not deep equal
+diff (-want +got):
+%s
+got:
+  []qt_test.record{
+      {
+          ID:   1,
+          Name: "",
+          Meta: qt_test.recordMeta{Owner:"bob", Created:0},
+      },
+  }
+want:
+  []qt_test.record{
+      {
+          ID:   1,
+          Name: "",
+          Meta: qt_test.recordMeta{},
+      },
+  }
+got as Go source:
+  []qt_test.record{
+  	{
+  		ID: 1,
+  		Meta: qt_test.recordMeta{
+  			Owner: "bob",
+  		},
+  	},
+  }
+`, diff([]record{{ID: 1, Meta: recordMeta{Owner: "bob"}}}, []record{{ID: 1}})),
 }, {
 	about:   "CmpEquals: different values, long output",
 	checker: qt.CmpEquals([]any{cmpEqualsWant, "extra line 1", "extra line 2", "extra line 3"}, []any{cmpEqualsWant, "extra line 1"}),
@@ -510,6 +663,38 @@ got:
 want:
   []int{3, 2, 1}
 `, diff([]int{1, 2, 4}, []int{3, 2, 1}, sameInts)),
+}, {
+	about:   "CmpEquals: same instants with CmpTimeEqual",
+	checker: qt.CmpEquals([]time.Time{goTime}, []time.Time{goTime.In(time.FixedZone("UTC+1", 3600))}, qt.CmpTimeEqual),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  []time.Time{
+      time.Date(2012, time.March, 28, 0, 0, 0, 0, time.UTC),
+  }
+want:
+  []time.Time{
+      time.Date(2012, time.March, 28, 1, 0, 0, 0, time.Location("UTC+1")),
+  }
+`,
+}, {
+	about:   "CmpEquals: different instants with CmpTimeEqual",
+	checker: qt.CmpEquals(struct{ T time.Time }{goTime}, struct{ T time.Time }{goTime.Add(time.Second)}, qt.CmpTimeEqual),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  values are not deep equal
+diff (-want +got):
+%s
+got:
+  struct { T time.Time }{
+      T:  time.Date(2012, time.March, 28, 0, 0, 0, 0, time.UTC),
+  }
+want:
+  struct { T time.Time }{
+      T:  time.Date(2012, time.March, 28, 0, 0, 1, 0, time.UTC),
+  }
+`, diff(struct{ T time.Time }{goTime}, struct{ T time.Time }{goTime.Add(time.Second)}, qt.CmpTimeEqual)),
 }, {
 	about: "DeepEquals: structs with unexported fields not allowed",
 	checker: qt.DeepEquals(
@@ -575,1402 +760,5841 @@ want:
   s"2012-03-28 00:00:00 +0000 UTC"
 `, diff(goTime.Add(24*time.Hour), goTime)),
 }, {
-	about:   "ContentEquals: same values",
-	checker: qt.ContentEquals([]string{"these", "are", "the", "voyages"}, []string{"these", "are", "the", "voyages"}),
+	about:   "DeepEquals: functions",
+	checker: qt.DeepEquals(func() {}, func() {}),
+	expectedCheckFailure: `
+error:
+  bad check: cannot compare functions with DeepEquals; functions are only comparable to nil
+`,
+	expectedNegateFailure: `
+error:
+  bad check: cannot compare functions with DeepEquals; functions are only comparable to nil
+`,
+}, {
+	about: "DeepEquals: nested functions",
+	checker: qt.DeepEquals(struct {
+		F func()
+	}{F: func() {}}, struct {
+		F func()
+	}{F: func() {}}),
+	expectedCheckFailure: `
+error:
+  bad check: cannot compare functions with DeepEquals (found at root.F); functions are only comparable to nil
+`,
+	expectedNegateFailure: `
+error:
+  bad check: cannot compare functions with DeepEquals (found at root.F); functions are only comparable to nil
+`,
+}, {
+	about:   "DeepEquals: nil functions",
+	checker: qt.DeepEquals((func())(nil), nil),
 	expectedNegateFailure: `
 error:
   unexpected success
 got:
-  []string{"these", "are", "the", "voyages"}
+  func() {...}
 want:
   <same as "got">
 `,
 }, {
-	about:   "ContentEquals: same contents",
-	checker: qt.ContentEquals([]int{1, 2, 3}, []int{3, 2, 1}),
-	expectedNegateFailure: `
+	about:   "DeepEquals: values formatted identically",
+	checker: qt.DeepEquals(math.NaN(), math.NaN()),
+	expectedCheckFailure: fmt.Sprintf(`
 error:
-  unexpected success
+  values are not deep equal
+note:
+  values are formatted identically; they may differ by pointer identity or contain NaN
+diff (-want +got):
+%s
 got:
-  []int{1, 2, 3}
+  float64(NaN)
 want:
-  []int{3, 2, 1}
-`,
+  float64(NaN)
+`, diff(math.NaN(), math.NaN())),
 }, {
-	about: "ContentEquals: same contents on complex slice",
-	checker: qt.ContentEquals(
-		[]struct {
-			Strings []any
-			Ints    []int
-		}{cmpEqualsGot, cmpEqualsGot, cmpEqualsWant},
-		[]struct {
-			Strings []any
-			Ints    []int
-		}{cmpEqualsWant, cmpEqualsGot, cmpEqualsGot},
-	),
+	about:   "DeepEqualsEquatingEmpty: nil and empty slices",
+	checker: qt.DeepEqualsEquatingEmpty([]int(nil), []int{}),
 	expectedNegateFailure: `
 error:
   unexpected success
 got:
-  []struct { Strings []interface {}; Ints []int }{
-      {
-          Strings: {
-              "who",
-              "dalek",
-          },
-          Ints: {42, 47},
-      },
-      {
-          Strings: {
-              "who",
-              "dalek",
-          },
-          Ints: {42, 47},
-      },
-      {
-          Strings: {
-              "who",
-              "dalek",
-          },
-          Ints: {42},
-      },
-  }
+  []int(nil)
 want:
-  []struct { Strings []interface {}; Ints []int }{
-      {
-          Strings: {
-              "who",
-              "dalek",
-          },
-          Ints: {42},
-      },
-      {
-          Strings: {
-              "who",
-              "dalek",
-          },
-          Ints: {42, 47},
-      },
-      {
-          Strings: {
-              "who",
-              "dalek",
-          },
-          Ints: {42, 47},
-      },
-  }
-`}, {
-	about: "ContentEquals: same contents on a nested slice",
-	checker: qt.ContentEquals(
-		struct {
-			Nums []int
-		}{
-			Nums: []int{1, 2, 3, 4},
-		},
-		struct {
-			Nums []int
-		}{
-			Nums: []int{4, 3, 2, 1},
-		},
-	),
+  []int{}
+`,
+}, {
+	about:   "DeepEqualsEquatingEmpty: nested nil and empty maps",
+	checker: qt.DeepEqualsEquatingEmpty(map[string][]string{"a": nil}, map[string][]string{"a": {}}),
 	expectedNegateFailure: `
 error:
   unexpected success
 got:
-  struct { Nums []int }{
-      Nums: {1, 2, 3, 4},
+  map[string][]string{
+      "a": nil,
   }
 want:
-  struct { Nums []int }{
-      Nums: {4, 3, 2, 1},
+  map[string][]string{
+      "a": {},
   }
 `,
 }, {
-	about:   "ContentEquals: slices of different type",
-	checker: qt.ContentEquals[any]([]string{"bad", "wolf"}, []any{"bad", "wolf"}),
+	about:   "DeepEqualsEquatingEmpty: different values",
+	checker: qt.DeepEqualsEquatingEmpty([]int(nil), []int{1}),
 	expectedCheckFailure: fmt.Sprintf(`
 error:
   values are not deep equal
 diff (-want +got):
 %s
 got:
-  []string{"bad", "wolf"}
+  []int(nil)
 want:
-  []interface {}{
-      "bad",
-      "wolf",
-  }
-`, diff([]string{"bad", "wolf"}, []any{"bad", "wolf"})),
+  []int{1}
+`, diff([]int(nil), []int{1})),
 }, {
-	about:   "Matches: perfect match",
-	checker: qt.Matches("exterminate", "exterminate"),
+	about:   "DeepEqualsApprox: values within margin",
+	checker: qt.DeepEqualsApprox(map[string][]float64{"a": {1.0001, 2}}, map[string][]float64{"a": {1, 2.0001}}, 0, 0.001),
 	expectedNegateFailure: `
 error:
   unexpected success
-got value:
-  "exterminate"
-regexp:
-  <same as "got value">
+got:
+  map[string][]float64{
+      "a": {1.0001, 2},
+  }
+want:
+  map[string][]float64{
+      "a": {1, 2.0001},
+  }
 `,
 }, {
-	about:   "Matches: match",
-	checker: qt.Matches("these are the voyages", "these are the .*"),
+	about:   "DeepEqualsApprox: values within fraction",
+	checker: qt.DeepEqualsApprox([]float64{100, 1000}, []float64{101, 1010}, 0.01, 0),
 	expectedNegateFailure: `
 error:
   unexpected success
-got value:
-  "these are the voyages"
-regexp:
-  "these are the .*"
+got:
+  []float64{100, 1000}
+want:
+  []float64{101, 1010}
 `,
 }, {
-	about:   "Matches: mismatch",
-	checker: qt.Matches("voyages", "these are the voyages"),
-	expectedCheckFailure: `
+	about:   "DeepEqualsApprox: values too far apart",
+	checker: qt.DeepEqualsApprox([]float64{1, 2.5}, []float64{1, 2}, 0.1, 0.1),
+	expectedCheckFailure: fmt.Sprintf(`
 error:
-  value does not match regexp
-got value:
-  "voyages"
-regexp:
-  "these are the voyages"
-`,
+  values are not deep equal
+diff (-want +got):
+%s
+got:
+  []float64{1, 2.5}
+want:
+  []float64{1, 2}
+`, diff([]float64{1, 2.5}, []float64{1, 2})),
 }, {
-	about:   "Matches: empty pattern",
-	checker: qt.Matches("these are the voyages", ""),
+	about:   "DeepEqualsApprox: invalid fraction",
+	checker: qt.DeepEqualsApprox(1.0, 1.0, -1, 0),
 	expectedCheckFailure: `
 error:
-  value does not match regexp
-got value:
-  "these are the voyages"
-regexp:
-  ""
+  bad check: invalid approximation: fraction -1, margin 0
 `,
-}, {
-	about:   "Matches: complex pattern",
-	checker: qt.Matches("end of the universe", "bad wolf|end of the .*"),
 	expectedNegateFailure: `
 error:
-  unexpected success
-got value:
-  "end of the universe"
-regexp:
-  "bad wolf|end of the .*"
+  bad check: invalid approximation: fraction -1, margin 0
 `,
 }, {
-	about:   "Matches: invalid pattern",
-	checker: qt.Matches("voyages", "("),
-	expectedCheckFailure: `
-error:
-  bad check: cannot compile regexp: error parsing regexp: missing closing ): ` + "`^(()$`" + `
-regexp:
-  "("
-`,
+	about:   "DeepEqualsIgnoring: same values except ignored fields",
+	checker: qt.DeepEqualsIgnoring(record{ID: 1, Name: "a", Meta: recordMeta{Owner: "bob", Created: 1}}, record{ID: 2, Name: "a", Meta: recordMeta{Owner: "bob", Created: 2}}, "ID", "Meta.Created"),
 	expectedNegateFailure: `
 error:
-  bad check: cannot compile regexp: error parsing regexp: missing closing ): ` + "`^(()$`" + `
-regexp:
-  "("
+  unexpected success
+got:
+  qt_test.record{
+      ID:   1,
+      Name: "a",
+      Meta: qt_test.recordMeta{Owner:"bob", Created:1},
+  }
+want:
+  qt_test.record{
+      ID:   2,
+      Name: "a",
+      Meta: qt_test.recordMeta{Owner:"bob", Created:2},
+  }
 `,
 }, {
-	about:   "Matches: match with pre-compiled regexp",
-	checker: qt.Matches("resistance is futile", regexp.MustCompile("resistance is (futile|useful)")),
+	about:   "DeepEqualsIgnoring: slice of pointers",
+	checker: qt.DeepEqualsIgnoring([]*record{{ID: 1, Name: "a"}}, []*record{{ID: 2, Name: "a"}}, "ID"),
 	expectedNegateFailure: `
 error:
   unexpected success
-got value:
-  "resistance is futile"
-regexp:
-  s"resistance is (futile|useful)"
+got:
+  []*qt_test.record{
+      &qt_test.record{
+          ID:   1,
+          Name: "a",
+          Meta: qt_test.recordMeta{},
+      },
+  }
+want:
+  []*qt_test.record{
+      &qt_test.record{
+          ID:   2,
+          Name: "a",
+          Meta: qt_test.recordMeta{},
+      },
+  }
 `,
 }, {
-	about:   "Matches: mismatch with pre-compiled regexp",
-	checker: qt.Matches("resistance is cool", regexp.MustCompile("resistance is (futile|useful)")),
+	about:   "DeepEqualsIgnoring: different values",
+	checker: qt.DeepEqualsIgnoring(record{ID: 1, Name: "a"}, record{ID: 2, Name: "b"}, "ID"),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  values are not deep equal
+diff (-want +got):
+%s
+got:
+  qt_test.record{
+      ID:   1,
+      Name: "a",
+      Meta: qt_test.recordMeta{},
+  }
+want:
+  qt_test.record{
+      ID:   2,
+      Name: "b",
+      Meta: qt_test.recordMeta{},
+  }
+`, diff(record{ID: 1, Name: "a"}, record{ID: 2, Name: "b"}, cmpopts.IgnoreFields(record{}, "ID"))),
+}, {
+	about:   "DeepEqualsIgnoring: unknown field",
+	checker: qt.DeepEqualsIgnoring(record{}, record{}, "Meta.Updated"),
 	expectedCheckFailure: `
 error:
-  value does not match regexp
-got value:
-  "resistance is cool"
-regexp:
-  s"resistance is (futile|useful)"
+  bad check: cannot ignore fields on type qt_test.record: Meta.Updated: does not exist
+`,
+	expectedNegateFailure: `
+error:
+  bad check: cannot ignore fields on type qt_test.record: Meta.Updated: does not exist
 `,
 }, {
-	about:   "Matches: match with pre-compiled multi-line regexp",
-	checker: qt.Matches("line 1\nline 2", regexp.MustCompile(`line \d\nline \d`)),
+	about:   "DeepEqualsIgnoring: no struct type",
+	checker: qt.DeepEqualsIgnoring([]int{1}, []int{1}, "ID"),
+	expectedCheckFailure: `
+error:
+  bad check: cannot ignore fields on type []int: no struct type found
+`,
 	expectedNegateFailure: `
 error:
-  unexpected success
-got value:
-  "line 1\nline 2"
-regexp:
-  s"line \\d\\nline \\d"
+  bad check: cannot ignore fields on type []int: no struct type found
 `,
 }, {
-	about:   "ErrorMatches: perfect match",
-	checker: qt.ErrorMatches(errBadWolf, "bad wolf"),
+	about:   "FieldEquals: success",
+	checker: qt.FieldEquals(outerJSON, "Second[0].First", "hello"),
 	expectedNegateFailure: `
 error:
   unexpected success
-got error:
-  bad wolf
-    file:line
-regexp:
-  "bad wolf"
+got:
+  &qt_test.OuterJSON{
+      First:  47.11,
+      Second: {
+          &qt_test.InnerJSON{
+              First:  "hello",
+              Second: 42,
+              Third:  {"ok":true},
+          },
+          (*qt_test.InnerJSON)(nil),
+      },
+  }
+path:
+  Second[0].First
+want:
+  "hello"
 `,
 }, {
-	about:   "ErrorMatches: match",
-	checker: qt.ErrorMatches(errBadWolf, "bad .*"),
+	about:   "FieldEquals: map key",
+	checker: qt.FieldEquals(map[int][]bool{1: {false, true}}, "[1][1]", true),
 	expectedNegateFailure: `
 error:
   unexpected success
-got error:
-  bad wolf
-    file:line
-regexp:
-  "bad .*"
+got:
+  map[int][]bool{
+      1:  {false, true},
+  }
+path:
+  [1][1]
+want:
+  bool(true)
 `,
 }, {
-	about:   "ErrorMatches: mismatch",
-	checker: qt.ErrorMatches(errBadWolf, "exterminate"),
+	about:   "FieldEquals: failure",
+	checker: qt.FieldEquals(outerJSON, "Second[0].Third", map[string]bool{"ok": false}),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  values are not deep equal
+path:
+  Second[0].Third
+diff (-want +got):
+%s
+got:
+  map[string]bool{"ok":true}
+want:
+  map[string]bool{"ok":false}
+`, diff(any(map[string]bool{"ok": true}), any(map[string]bool{"ok": false}))),
+}, {
+	about:   "FieldEquals: nil value",
+	checker: qt.FieldEquals(outerJSON, "Second[1].First", "hello"),
 	expectedCheckFailure: `
 error:
-  error does not match regexp
-got error:
-  bad wolf
-    file:line
-regexp:
-  "exterminate"
+  cannot resolve path: nil value at got.Second[1]
+got:
+  &qt_test.OuterJSON{
+      First:  47.11,
+      Second: {
+          &qt_test.InnerJSON{
+              First:  "hello",
+              Second: 42,
+              Third:  {"ok":true},
+          },
+          (*qt_test.InnerJSON)(nil),
+      },
+  }
+path:
+  Second[1].First
+want:
+  "hello"
 `,
 }, {
-	about:   "ErrorMatches: empty pattern",
-	checker: qt.ErrorMatches(errBadWolf, ""),
+	about:   "FieldEquals: index out of range",
+	checker: qt.FieldEquals([]int{1}, "[1]", 1),
 	expectedCheckFailure: `
 error:
-  error does not match regexp
-got error:
-  bad wolf
-    file:line
-regexp:
-  ""
+  cannot resolve path: index 1 out of range at got (length 1)
+got:
+  []int{1}
+path:
+  [1]
+want:
+  int(1)
 `,
 }, {
-	about:   "ErrorMatches: complex pattern",
-	checker: qt.ErrorMatches(errBadWolf, "bad wolf|end of the universe"),
-	expectedNegateFailure: `
+	about:   "FieldEquals: key not found",
+	checker: qt.FieldEquals(outerJSON, "Second[0].Third[ko]", true),
+	expectedCheckFailure: `
 error:
-  unexpected success
-got error:
-  bad wolf
-    file:line
-regexp:
-  "bad wolf|end of the universe"
+  cannot resolve path: key "ko" not found at got.Second[0].Third
+got:
+  &qt_test.OuterJSON{
+      First:  47.11,
+      Second: {
+          &qt_test.InnerJSON{
+              First:  "hello",
+              Second: 42,
+              Third:  {"ok":true},
+          },
+          (*qt_test.InnerJSON)(nil),
+      },
+  }
+path:
+  Second[0].Third[ko]
+want:
+  bool(true)
 `,
 }, {
-	about:   "ErrorMatches: invalid pattern",
-	checker: qt.ErrorMatches(errBadWolf, "("),
+	about:   "FieldEquals: no such field",
+	checker: qt.FieldEquals(outerJSON, "Second[0].Fourth", 1),
 	expectedCheckFailure: `
 error:
-  bad check: cannot compile regexp: error parsing regexp: missing closing ): ` + "`^(()$`" + `
-regexp:
-  "("
+  bad check: no field "Fourth" in qt_test.InnerJSON at got.Second[0]
 `,
 	expectedNegateFailure: `
 error:
-  bad check: cannot compile regexp: error parsing regexp: missing closing ): ` + "`^(()$`" + `
-regexp:
-  "("
+  bad check: no field "Fourth" in qt_test.InnerJSON at got.Second[0]
 `,
 }, {
-	about:   "ErrorMatches: nil error",
-	checker: qt.ErrorMatches(nil, "some pattern"),
+	about:   "FieldEquals: unexported field",
+	checker: qt.FieldEquals(targetErr, "msg", "target"),
 	expectedCheckFailure: `
 error:
-  got nil error but want non-nil
-got error:
-  nil
-regexp:
-  "some pattern"
+  bad check: cannot get unexported field "msg" of qt_test.errTarget at got
 `,
-}, {
-	about:   "ErrorMatches: match with pre-compiled regexp",
-	checker: qt.ErrorMatches(errBadWolf, regexp.MustCompile("bad (wolf|dog)")),
 	expectedNegateFailure: `
 error:
-  unexpected success
-got error:
-  bad wolf
-    file:line
-regexp:
-  s"bad (wolf|dog)"
+  bad check: cannot get unexported field "msg" of qt_test.errTarget at got
 `,
 }, {
-	about:   "ErrorMatches: match with pre-compiled multi-line regexp",
-	checker: qt.ErrorMatches(errBadWolfMultiLine, regexp.MustCompile(`bad (wolf|dog)\nfaulty (logic|statement)`)),
+	about:   "FieldEquals: field of non struct",
+	checker: qt.FieldEquals(outerJSON, "First.Value", 1),
+	expectedCheckFailure: `
+error:
+  bad check: cannot get field "Value" of float64 at got.First
+`,
 	expectedNegateFailure: `
 error:
-  unexpected success
-got error:
-  bad wolf
-  faulty logic
-    file:line
-regexp:
-  s"bad (wolf|dog)\\nfaulty (logic|statement)"
+  bad check: cannot get field "Value" of float64 at got.First
 `,
 }, {
-	about:   "ErrorMatches: mismatch with pre-compiled regexp",
-	checker: qt.ErrorMatches(errBadWolf, regexp.MustCompile("good (wolf|dog)")),
+	about:   "FieldEquals: invalid index",
+	checker: qt.FieldEquals(outerJSON, "Second[first]", 1),
 	expectedCheckFailure: `
 error:
-  error does not match regexp
-got error:
-  bad wolf
-    file:line
-regexp:
-  s"good (wolf|dog)"
+  bad check: invalid index "first" for []*qt_test.InnerJSON at got.Second
 `,
-}, {
-	about:   "PanicMatches: perfect match",
-	checker: qt.PanicMatches(func() { panic("error: bad wolf") }, "error: bad wolf"),
 	expectedNegateFailure: `
 error:
-  unexpected success
-panic value:
-  "error: bad wolf"
-function:
-  func() {...}
-regexp:
-  <same as "panic value">
+  bad check: invalid index "first" for []*qt_test.InnerJSON at got.Second
 `,
 }, {
-	about:   "PanicMatches: match",
-	checker: qt.PanicMatches(func() { panic("error: bad wolf") }, "error: .*"),
+	about:   "FieldEquals: missing closing bracket",
+	checker: qt.FieldEquals(outerJSON, "Second[0", 1),
+	expectedCheckFailure: `
+error:
+  bad check: invalid path "Second[0": missing closing bracket
+`,
 	expectedNegateFailure: `
 error:
-  unexpected success
-panic value:
-  "error: bad wolf"
-function:
-  func() {...}
-regexp:
-  "error: .*"
+  bad check: invalid path "Second[0": missing closing bracket
 `,
 }, {
-	about:   "PanicMatches: mismatch",
-	checker: qt.PanicMatches(func() { panic("error: bad wolf") }, "error: exterminate"),
+	about:   "FieldEquals: empty path",
+	checker: qt.FieldEquals(outerJSON, "", 1),
 	expectedCheckFailure: `
 error:
-  panic value does not match regexp
-panic value:
-  "error: bad wolf"
-function:
-  func() {...}
-regexp:
-  "error: exterminate"
+  bad check: empty path
 `,
-}, {
-	about:   "PanicMatches: empty pattern",
-	checker: qt.PanicMatches(func() { panic("error: bad wolf") }, ""),
-	expectedCheckFailure: `
+	expectedNegateFailure: `
 error:
-  panic value does not match regexp
-panic value:
-  "error: bad wolf"
-function:
-  func() {...}
-regexp:
-  ""
+  bad check: empty path
 `,
 }, {
-	about:   "PanicMatches: complex pattern",
-	checker: qt.PanicMatches(func() { panic("bad wolf") }, "bad wolf|end of the universe"),
+	about:   "NoZeroFields: all fields set",
+	checker: qt.NoZeroFields(record{ID: 1, Name: "a", Meta: recordMeta{Owner: "bob"}}),
 	expectedNegateFailure: `
 error:
   unexpected success
-panic value:
-  "bad wolf"
-function:
-  func() {...}
-regexp:
-  "bad wolf|end of the universe"
+got:
+  qt_test.record{
+      ID:   1,
+      Name: "a",
+      Meta: qt_test.recordMeta{Owner:"bob", Created:0},
+  }
 `,
 }, {
-	about:   "PanicMatches: invalid pattern",
-	checker: qt.PanicMatches(func() { panic("error: bad wolf") }, "("),
+	about:   "NoZeroFields: zero fields",
+	checker: qt.NoZeroFields(&record{Name: "a"}),
 	expectedCheckFailure: `
 error:
-  bad check: cannot compile regexp: error parsing regexp: missing closing ): ` + "`^(()$`" + `
-panic value:
-  "error: bad wolf"
-regexp:
-  "("
+  struct has zero-valued fields
+zero fields:
+  ID
+  Meta
+got:
+  &qt_test.record{
+      ID:   0,
+      Name: "a",
+      Meta: qt_test.recordMeta{},
+  }
 `,
+}, {
+	about:   "NoZeroFields: ignored fields",
+	checker: qt.NoZeroFields(record{Name: "a"}, "ID", "Meta"),
 	expectedNegateFailure: `
 error:
-  bad check: cannot compile regexp: error parsing regexp: missing closing ): ` + "`^(()$`" + `
-panic value:
-  "error: bad wolf"
-regexp:
-  "("
+  unexpected success
+got:
+  qt_test.record{
+      ID:   0,
+      Name: "a",
+      Meta: qt_test.recordMeta{},
+  }
 `,
 }, {
-	about:   "PanicMatches: no panic",
-	checker: qt.PanicMatches(func() {}, ".*"),
+	about:   "NoZeroFields: unknown ignored field",
+	checker: qt.NoZeroFields(record{}, "Meta.Owner"),
 	expectedCheckFailure: `
 error:
-  function did not panic
-function:
-  func() {...}
-regexp:
-  ".*"
+  bad check: ignored field "Meta.Owner" not found in qt_test.record
 `,
-}, {
-	about:   "PanicMatches: match with pre-compiled regexp",
-	checker: qt.PanicMatches(func() { panic("error: bad wolf") }, regexp.MustCompile("error: bad (wolf|dog)")),
 	expectedNegateFailure: `
 error:
-  unexpected success
-panic value:
-  "error: bad wolf"
-function:
-  func() {...}
-regexp:
-  s"error: bad (wolf|dog)"
+  bad check: ignored field "Meta.Owner" not found in qt_test.record
 `,
 }, {
-	about:   "PanicMatches: match with pre-compiled multi-line regexp",
-	checker: qt.PanicMatches(func() { panic("error: bad wolf\nfaulty logic") }, regexp.MustCompile(`error: bad (wolf|dog)\nfaulty (logic|statement)`)),
+	about:   "NoZeroFields: not a struct",
+	checker: qt.NoZeroFields(42),
+	expectedCheckFailure: `
+error:
+  bad check: value of type int is not a struct or a pointer to a struct
+`,
 	expectedNegateFailure: `
 error:
-  unexpected success
-panic value:
-  "error: bad wolf\nfaulty logic"
-function:
-  func() {...}
-regexp:
-  s"error: bad (wolf|dog)\\nfaulty (logic|statement)"
+  bad check: value of type int is not a struct or a pointer to a struct
 `,
 }, {
-	about:   "PanicMatches: mismatch with pre-compiled regexp",
-	checker: qt.PanicMatches(func() { panic("error: bad wolf") }, regexp.MustCompile("good (wolf|dog)")),
+	about:   "NoZeroFieldsDeep: nested zero fields",
+	checker: qt.NoZeroFieldsDeep(record{ID: 1, Meta: recordMeta{Owner: "bob"}}, "Name"),
 	expectedCheckFailure: `
 error:
-  panic value does not match regexp
-panic value:
-  "error: bad wolf"
-function:
-  func() {...}
-regexp:
-  s"good (wolf|dog)"
+  struct has zero-valued fields
+zero fields:
+  Meta.Created
+got:
+  qt_test.record{
+      ID:   1,
+      Name: "",
+      Meta: qt_test.recordMeta{Owner:"bob", Created:0},
+  }
 `,
 }, {
-	about:   "IsNil: nil",
-	checker: qt.IsNil(any(nil)),
+	about:   "NoZeroFieldsDeep: ignored nested field",
+	checker: qt.NoZeroFieldsDeep(record{ID: 1, Name: "a", Meta: recordMeta{Owner: "bob"}}, "Meta.Created"),
 	expectedNegateFailure: `
 error:
-  got <nil> but want non-nil
+  unexpected success
 got:
-  nil
+  qt_test.record{
+      ID:   1,
+      Name: "a",
+      Meta: qt_test.recordMeta{Owner:"bob", Created:0},
+  }
 `,
 }, {
-	about:   "IsNil: nil pointer to struct",
-	checker: qt.IsNil((*struct{})(nil)),
+	about:   "ContentEquals: same values",
+	checker: qt.ContentEquals([]string{"these", "are", "the", "voyages"}, []string{"these", "are", "the", "voyages"}),
 	expectedNegateFailure: `
 error:
-  got nil ptr but want non-nil
+  unexpected success
 got:
-  (*struct {})(nil)
+  []string{"these", "are", "the", "voyages"}
+want:
+  <same as "got">
 `,
 }, {
-	about:   "IsNil: nil func",
-	checker: qt.IsNil((func())(nil)),
+	about:   "ContentEquals: same contents",
+	checker: qt.ContentEquals([]int{1, 2, 3}, []int{3, 2, 1}),
 	expectedNegateFailure: `
 error:
-  got nil func but want non-nil
+  unexpected success
 got:
-  func() {...}
+  []int{1, 2, 3}
+want:
+  []int{3, 2, 1}
 `,
 }, {
-	about:   "IsNil: nil map",
-	checker: qt.IsNil((map[string]string)(nil)),
+	about: "ContentEquals: same contents on complex slice",
+	checker: qt.ContentEquals(
+		[]struct {
+			Strings []any
+			Ints    []int
+		}{cmpEqualsGot, cmpEqualsGot, cmpEqualsWant},
+		[]struct {
+			Strings []any
+			Ints    []int
+		}{cmpEqualsWant, cmpEqualsGot, cmpEqualsGot},
+	),
 	expectedNegateFailure: `
 error:
-  got nil map but want non-nil
+  unexpected success
 got:
-  map[string]string{}
-`,
-}, {
-	about:   "IsNil: nil slice",
-	checker: qt.IsNil(([]int)(nil)),
+  []struct { Strings []interface {}; Ints []int }{
+      {
+          Strings: {
+              "who",
+              "dalek",
+          },
+          Ints: {42, 47},
+      },
+      {
+          Strings: {
+              "who",
+              "dalek",
+          },
+          Ints: {42, 47},
+      },
+      {
+          Strings: {
+              "who",
+              "dalek",
+          },
+          Ints: {42},
+      },
+  }
+want:
+  []struct { Strings []interface {}; Ints []int }{
+      {
+          Strings: {
+              "who",
+              "dalek",
+          },
+          Ints: {42},
+      },
+      {
+          Strings: {
+              "who",
+              "dalek",
+          },
+          Ints: {42, 47},
+      },
+      {
+          Strings: {
+              "who",
+              "dalek",
+          },
+          Ints: {42, 47},
+      },
+  }
+`}, {
+	about: "ContentEquals: same contents on a nested slice",
+	checker: qt.ContentEquals(
+		struct {
+			Nums []int
+		}{
+			Nums: []int{1, 2, 3, 4},
+		},
+		struct {
+			Nums []int
+		}{
+			Nums: []int{4, 3, 2, 1},
+		},
+	),
 	expectedNegateFailure: `
 error:
-  got nil slice but want non-nil
+  unexpected success
 got:
-  []int(nil)
+  struct { Nums []int }{
+      Nums: {1, 2, 3, 4},
+  }
+want:
+  struct { Nums []int }{
+      Nums: {4, 3, 2, 1},
+  }
 `,
 }, {
-	about:   "IsNil: nil error-implementing type",
-	checker: qt.IsNil(error((*errTest)(nil))),
-	// TODO e<nil> isn't great here - perhaps we should
-	// mention the type too.
-	expectedCheckFailure: `
+	about:   "ContentEquals: slices of different type",
+	checker: qt.ContentEquals[any]([]string{"bad", "wolf"}, []any{"bad", "wolf"}),
+	expectedCheckFailure: fmt.Sprintf(`
 error:
-  got non-nil value
+  values are not deep equal
+diff (-want +got):
+%s
+got:
+  []string{"bad", "wolf"}
+want:
+  []interface {}{
+      "bad",
+      "wolf",
+  }
+`, diff([]string{"bad", "wolf"}, []any{"bad", "wolf"})),
+}, {
+	about:   "OneOf: match",
+	checker: qt.OneOf("pending", "pending", "running", "done"),
+	expectedNegateFailure: `
+error:
+  unexpected success
 got:
-  e<nil>
+  "pending"
+want one of:
+  []string{"pending", "running", "done"}
 `,
 }, {
-	about:   "IsNil: not nil",
-	checker: qt.IsNil([]int{}),
+	about:   "OneOf: mismatch",
+	checker: qt.OneOf(42, 1, 2, 3),
 	expectedCheckFailure: `
 error:
-  got non-nil value
+  value is not one of the allowed values
 got:
-  []int{}
+  int(42)
+want one of:
+  []int{1, 2, 3}
 `,
 }, {
-	about:   "IsNil: error is not nil",
-	checker: qt.IsNil(error(errBadWolf)),
+	about:   "OneOf: no values",
+	checker: qt.OneOf(42),
 	expectedCheckFailure: `
 error:
-  got non-nil value
-got:
-  bad wolf
-    file:line
+  bad check: no values provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: no values provided
 `,
 }, {
-	about:   "IsNotNil: success",
-	checker: qt.IsNotNil(any(42)),
+	about:   "DeepOneOf: match",
+	checker: qt.DeepOneOf([]int{1, 2}, []int{1}, []int{1, 2}),
 	expectedNegateFailure: `
 error:
-  got non-nil value
+  unexpected success
 got:
-  int(42)
+  []int{1, 2}
+want one of:
+  [][]int{
+      {1},
+      {1, 2},
+  }
 `,
 }, {
-	about:   "IsNotNil: failure",
-	checker: qt.IsNotNil[any](nil),
-	expectedCheckFailure: `
+	about:   "DeepOneOf: mismatch",
+	checker: qt.DeepOneOf(map[string]int{"a": 1}, map[string]int{"a": 2}, map[string]int{"a": 1, "b": 2}),
+	expectedCheckFailure: fmt.Sprintf(`
 error:
-  got <nil> but want non-nil
+  value is not one of the allowed values
+diff with value 0 (-want +got):
+%s
+diff with value 1 (-want +got):
+%s
 got:
-  nil
+  map[string]int{"a":1}
+want one of:
+  []map[string]int{
+      {"a":2},
+      {"a":1, "b":2},
+  }
+`, diff(map[string]int{"a": 1}, map[string]int{"a": 2}), diff(map[string]int{"a": 1}, map[string]int{"a": 1, "b": 2})),
+}, {
+	about:   "DeepOneOf: unexported fields",
+	checker: qt.DeepOneOf(struct{ answer int }{answer: 42}, struct{ answer int }{answer: 47}),
+	expectedCheckFailure: `
+error:
+  bad check: cannot handle unexported field at root.answer:
+  	"github.com/go-quicktest/qt_test".(struct { answer int })
+  consider using a custom Comparer; if you control the implementation of type, you can also consider using an Exporter, AllowUnexported, or cmpopts.IgnoreUnexported
+`,
+	expectedNegateFailure: `
+error:
+  bad check: cannot handle unexported field at root.answer:
+  	"github.com/go-quicktest/qt_test".(struct { answer int })
+  consider using a custom Comparer; if you control the implementation of type, you can also consider using an Exporter, AllowUnexported, or cmpopts.IgnoreUnexported
 `,
 }, {
-	about:   "HasLen: arrays with the same length",
-	checker: qt.HasLen([4]string{"these", "are", "the", "voyages"}, 4),
+	about:   "SetEquals: same elements",
+	checker: qt.SetEquals([]string{"a", "b", "a"}, []string{"b", "a"}),
 	expectedNegateFailure: `
 error:
   unexpected success
-len(got):
-  int(4)
 got:
-  [4]string{"these", "are", "the", "voyages"}
-want length:
-  <same as "len(got)">
+  []string{"a", "b", "a"}
+want:
+  []string{"b", "a"}
 `,
 }, {
-	about:   "HasLen: pointers to array with the same length",
-	checker: qt.HasLen(&[4]string{"these", "are", "the", "voyages"}, 4),
+	about:   "SetEquals: nil and empty",
+	checker: qt.SetEquals([]int(nil), []int{}),
 	expectedNegateFailure: `
 error:
   unexpected success
-len(got):
-  int(4)
 got:
-  &[4]string{"these", "are", "the", "voyages"}
-want length:
-  <same as "len(got)">
+  []int(nil)
+want:
+  []int{}
 `,
 }, {
-	about:   "HasLen: channels with the same length",
-	checker: qt.HasLen(chInt, 2),
-	expectedNegateFailure: fmt.Sprintf(`
+	about:   "SetEquals: different elements",
+	checker: qt.SetEquals([]string{"a", "b", "c", "c"}, []string{"d", "b", "a", "e", "d"}),
+	expectedCheckFailure: `
 error:
-  unexpected success
-len(got):
-  int(2)
+  sets are not equal
+unexpected elements:
+  []string{"c"}
+missing elements:
+  []string{"d", "e"}
 got:
-  (chan int)(%v)
-want length:
-  <same as "len(got)">
-`, chInt),
+  []string{"a", "b", "c", "c"}
+want:
+  []string{"d", "b", "a", "e", "d"}
+`,
 }, {
-	about:   "HasLen: maps with the same length",
-	checker: qt.HasLen(map[string]bool{"true": true}, 1),
-	expectedNegateFailure: `
+	about:   "SetEquals: missing elements only",
+	checker: qt.SetEquals([]int{1}, []int{1, 2}),
+	expectedCheckFailure: `
 error:
-  unexpected success
-len(got):
-  int(1)
+  sets are not equal
+missing elements:
+  []int{2}
 got:
-  map[string]bool{"true":true}
-want length:
-  <same as "len(got)">
+  []int{1}
+want:
+  []int{1, 2}
 `,
 }, {
-	about:   "HasLen: slices with the same length",
-	checker: qt.HasLen([]int{}, 0),
+	about:   "IsPermutationOf: permutation",
+	checker: qt.IsPermutationOf([]string{"c", "a", "b", "a"}, []string{"a", "a", "b", "c"}),
 	expectedNegateFailure: `
 error:
   unexpected success
-len(got):
-  int(0)
 got:
-  []int{}
-want length:
-  <same as "len(got)">
+  []string{"c", "a", "b", "a"}
+want:
+  []string{"a", "a", "b", "c"}
 `,
 }, {
-	about:   "HasLen: strings with the same length",
-	checker: qt.HasLen("these are the voyages", 21),
+	about:   "IsPermutationOf: different counts",
+	checker: qt.IsPermutationOf([]string{"c", "a", "b", "b", "d"}, []string{"a", "a", "b", "c"}),
+	expectedCheckFailure: `
+error:
+  slices are not permutations of each other
+count differences:
+  "a": got 1, want 2
+  "b": got 2, want 1
+  "d": got 1, want 0
+got:
+  []string{"c", "a", "b", "b", "d"}
+want:
+  []string{"a", "a", "b", "c"}
+`,
+}, {
+	about:   "IsPermutation: permutation",
+	checker: qt.IsPermutation([]int{2, 0, 1}),
 	expectedNegateFailure: `
 error:
   unexpected success
-len(got):
-  int(21)
 got:
-  "these are the voyages"
-want length:
-  <same as "len(got)">
+  []int{2, 0, 1}
+want:
+  []int{0, 1, 2}
 `,
 }, {
-	about:   "HasLen: arrays with different lengths",
-	checker: qt.HasLen([4]string{"these", "are", "the", "voyages"}, 0),
+	about:   "IsPermutation: not a permutation",
+	checker: qt.IsPermutation([]int{2, 0, 2}),
 	expectedCheckFailure: `
 error:
-  unexpected length
-len(got):
-  int(4)
+  slices are not permutations of each other
+count differences:
+  int(1): got 0, want 1
+  int(2): got 2, want 1
 got:
-  [4]string{"these", "are", "the", "voyages"}
-want length:
-  int(0)
+  []int{2, 0, 2}
+want:
+  []int{0, 1, 2}
 `,
 }, {
-	about:   "HasLen: channels with different lengths",
-	checker: qt.HasLen(chInt, 4),
-	expectedCheckFailure: fmt.Sprintf(`
+	about:   "MapKeys: same keys",
+	checker: qt.MapKeys(map[string]int{"a": 1, "b": 2}, []string{"b", "a"}),
+	expectedNegateFailure: `
 error:
-  unexpected length
-len(got):
-  int(2)
+  unexpected success
 got:
-  (chan int)(%v)
-want length:
-  int(4)
-`, chInt),
+  map[string]int{"a":1, "b":2}
+want keys:
+  []string{"b", "a"}
+`,
 }, {
-	about:   "HasLen: maps with different lengths",
-	checker: qt.HasLen(map[string]bool{"true": true}, 42),
+	about:   "MapKeys: different keys",
+	checker: qt.MapKeys(map[string]bool{"a": true, "b": false}, []string{"a", "c"}),
 	expectedCheckFailure: `
 error:
-  unexpected length
-len(got):
-  int(1)
+  sets are not equal
+unexpected keys:
+  []string{"b"}
+missing keys:
+  []string{"c"}
 got:
-  map[string]bool{"true":true}
-want length:
-  int(42)
+  map[string]bool{"a":true, "b":false}
+want keys:
+  []string{"a", "c"}
 `,
 }, {
-	about:   "HasLen: slices with different lengths",
-	checker: qt.HasLen([]int{42, 47}, 1),
+	about:   "MapKeys: unexpected keys in sorted order",
+	checker: qt.MapKeys(map[string]int{"e": 5, "b": 2, "d": 4, "a": 1, "c": 3}, []string{"a"}),
 	expectedCheckFailure: `
 error:
-  unexpected length
-len(got):
-  int(2)
+  sets are not equal
+unexpected keys:
+  []string{"b", "c", "d", "e"}
 got:
-  []int{42, 47}
-want length:
-  int(1)
+  map[string]int{"a":1, "b":2, "c":3, "d":4, "e":5}
+want keys:
+  []string{"a"}
 `,
 }, {
-	about:   "HasLen: strings with different lengths",
-	checker: qt.HasLen("these are the voyages", 42),
+	about:   "MapKeys: nil map",
+	checker: qt.MapKeys(map[int]int(nil), []int{1}),
 	expectedCheckFailure: `
 error:
-  unexpected length
-len(got):
-  int(21)
+  sets are not equal
+missing keys:
+  []int{1}
 got:
-  "these are the voyages"
-want length:
-  int(42)
+  map[int]int{}
+want keys:
+  <same as "missing keys">
 `,
 }, {
-	about:   "HasLen: value without a length",
-	checker: qt.HasLen(42, 42),
-	expectedCheckFailure: `
+	about:   "MapSubset: subset",
+	checker: qt.MapSubset(map[string]string{"a": "1"}, map[string]string{"a": "1", "b": "2"}),
+	expectedNegateFailure: `
 error:
-  bad check: first argument of type int has no length
+  unexpected success
 got:
-  int(42)
+  map[string]string{"a":"1"}
+want:
+  map[string]string{"a":"1", "b":"2"}
 `,
+}, {
+	about:   "MapSubset: empty",
+	checker: qt.MapSubset(nil, map[string]int{"a": 1}),
 	expectedNegateFailure: `
 error:
-  bad check: first argument of type int has no length
+  unexpected success
 got:
-  int(42)
+  map[string]int{}
+want:
+  map[string]int{"a":1}
 `,
 }, {
-	about:   "HasLen: pointer value without a length",
-	checker: qt.HasLen(&[]string{"arrays", "are", "fine", "but", "not", "slices"}, 1),
+	about:   "MapSubset: missing key",
+	checker: qt.MapSubset(map[string]int{"c": 3, "b": 2, "a": 1}, map[string]int{"a": 1}),
 	expectedCheckFailure: `
 error:
-  bad check: first argument of type *[]string has no length
+  key not found in want
+key:
+  "b"
 got:
-  &[]string{"arrays", "are", "fine", "but", "not", "slices"}
+  map[string]int{"a":1, "b":2, "c":3}
+want:
+  map[string]int{"a":1}
 `,
-	expectedNegateFailure: `
+}, {
+	about:   "MapSubset: different value",
+	checker: qt.MapSubset(map[string][]int{"a": {1, 2}}, map[string][]int{"a": {1, 3}}),
+	expectedCheckFailure: fmt.Sprintf(`
 error:
-  bad check: first argument of type *[]string has no length
+  values differ for key
+key:
+  "a"
+diff (-want +got):
+%s
 got:
-  &[]string{"arrays", "are", "fine", "but", "not", "slices"}
-`,
+  map[string][]int{
+      "a": {1, 2},
+  }
+want:
+  map[string][]int{
+      "a": {1, 3},
+  }
+`, diff([]int{1, 2}, []int{1, 3})),
 }, {
-	about:   "Implements: implements interface",
-	checker: qt.Implements[error](errBadWolf),
+	about: "MapEqualsIgnoring: equal without ignored keys",
+	checker: qt.MapEqualsIgnoring(
+		map[string]any{"id": 1, "created": "now"},
+		map[string]any{"id": 1, "created": "yesterday"},
+		"created", "missing",
+	),
 	expectedNegateFailure: `
 error:
   unexpected success
 got:
-  bad wolf
-    file:line
-want interface:
-  error
+  map[string]interface {}{
+      "created": "now",
+      "id":      int(1),
+  }
+want:
+  map[string]interface {}{
+      "created": "yesterday",
+      "id":      int(1),
+  }
+ignored keys:
+  []string{"created", "missing"}
 `,
 }, {
-	about:   "Implements: does not implement interface",
-	checker: qt.Implements[Fooer](errBadWolf),
-	expectedCheckFailure: `
+	about: "MapEqualsIgnoring: different values",
+	checker: qt.MapEqualsIgnoring(
+		map[string]int{"a": 1, "b": 2, "ts": 10},
+		map[string]int{"a": 1, "b": 3, "ts": 20},
+		"ts",
+	),
+	expectedCheckFailure: fmt.Sprintf(`
 error:
-  got value does not implement wanted interface
+  values are not deep equal
+diff (-want +got):
+%s
 got:
-  bad wolf
-    file:line
-want interface:
-  qt_test.Fooer
-`,
+  map[string]int{"a":1, "b":2}
+want:
+  map[string]int{"a":1, "b":3}
+`, diff(map[string]int{"a": 1, "b": 2}, map[string]int{"a": 1, "b": 3})),
 }, {
-	about:   "Implements: fails if got nil",
-	checker: qt.Implements[Fooer](nil),
-	expectedCheckFailure: `
+	about:   "MapEqualsIgnoring: nil and empty maps",
+	checker: qt.MapEqualsIgnoring(nil, map[int]bool{1: true}, 1),
+	expectedCheckFailure: fmt.Sprintf(`
 error:
-  got nil value but want non-nil
+  values are not deep equal
+note:
+  values are formatted identically; they may differ by pointer identity or contain NaN
+diff (-want +got):
+%s
 got:
-  nil
-`,
+  map[int]bool{}
+want:
+  map[int]bool{}
+`, diff(map[int]bool(nil), map[int]bool{})),
 }, {
-	about:   "Satisfies: success with an error",
-	checker: qt.Satisfies(qt.BadCheckf("bad wolf"), qt.IsBadCheck),
+	about:   "NoDuplicates: no duplicates",
+	checker: qt.NoDuplicates([]int{1, 2, 3}),
 	expectedNegateFailure: `
 error:
   unexpected success
 got:
-  e"bad check: bad wolf"
-predicate:
-  func(error) bool {...}
+  []int{1, 2, 3}
 `,
 }, {
-	about:   "Satisfies: success with an int",
-	checker: qt.Satisfies(42, func(v int) bool { return v == 42 }),
+	about:   "NoDuplicates: empty slice",
+	checker: qt.NoDuplicates([]string(nil)),
 	expectedNegateFailure: `
 error:
   unexpected success
 got:
-  int(42)
-predicate:
-  func(int) bool {...}
+  []string(nil)
 `,
 }, {
-	about:   "Satisfies: success with nil",
-	checker: qt.Satisfies([]int(nil), func(v []int) bool { return true }),
+	about:   "NoDuplicates: duplicates",
+	checker: qt.NoDuplicates([]string{"a", "b", "c", "b", "a", "b"}),
+	expectedCheckFailure: `
+error:
+  duplicate element found
+duplicate element:
+  "b"
+indices:
+  []int{1, 3, 5}
+got:
+  []string{"a", "b", "c", "b", "a", "b"}
+`,
+}, {
+	about:   "UniqueBy: unique keys",
+	checker: qt.UniqueBy([]cmpType{{Ints: []int{1}}, {Ints: []int{2}}}, func(v cmpType) int { return v.Ints[0] }),
 	expectedNegateFailure: `
 error:
   unexpected success
 got:
-  []int(nil)
-predicate:
-  func([]int) bool {...}
+  []qt_test.cmpType{
+      {
+          Strings: nil,
+          Ints:    {1},
+      },
+      {
+          Strings: nil,
+          Ints:    {2},
+      },
+  }
+key function:
+  func(qt_test.cmpType) int {...}
 `,
 }, {
-	about:   "Satisfies: failure with an error",
-	checker: qt.Satisfies(nil, qt.IsBadCheck),
+	about:   "UniqueBy: duplicate keys",
+	checker: qt.UniqueBy([]string{"apple", "banana", "avocado", "blueberry"}, func(s string) string { return s[:1] }),
 	expectedCheckFailure: `
 error:
-  value does not satisfy predicate function
+  duplicate key found
+duplicate key:
+  "a"
+element 0:
+  "apple"
+element 2:
+  "avocado"
 got:
-  nil
-predicate:
-  func(error) bool {...}
+  []string{"apple", "banana", "avocado", "blueberry"}
+key function:
+  func(string) string {...}
 `,
 }, {
-	about:   "Satisfies: failure with a string",
-	checker: qt.Satisfies("bad wolf", func(string) bool { return false }),
+	about:   "UniqueBy: nil key function",
+	checker: qt.UniqueBy([]int{1, 2}, (func(int) int)(nil)),
 	expectedCheckFailure: `
 error:
-  value does not satisfy predicate function
-got:
-  "bad wolf"
-predicate:
-  func(string) bool {...}
+  bad check: nil key function provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: nil key function provided
 `,
 }, {
-	about:   "IsTrue: success",
-	checker: qt.IsTrue(true),
+	about:   "Matches: perfect match",
+	checker: qt.Matches("exterminate", "exterminate"),
 	expectedNegateFailure: `
 error:
   unexpected success
-got:
-  bool(true)
-want:
-  <same as "got">
+got value:
+  "exterminate"
+regexp:
+  <same as "got value">
 `,
 }, {
-	about:   "IsTrue: failure",
-	checker: qt.IsTrue(false),
-	expectedCheckFailure: `
+	about:   "SliceCount: success",
+	checker: qt.SliceCount([]string{"error", "info", "error"}, "error", 2),
+	expectedNegateFailure: `
 error:
-  values are not equal
+  unexpected success
 got:
-  bool(false)
-want:
-  bool(true)
+  []string{"error", "info", "error"}
+element:
+  "error"
+want count:
+  int(2)
 `,
 }, {
-	about:   "IsTrue: success with subtype",
-	checker: qt.IsTrue(boolean(true)),
+	about:   "SliceCount: zero occurrences",
+	checker: qt.SliceCount([]int{1, 2, 3}, 4, 0),
 	expectedNegateFailure: `
 error:
   unexpected success
 got:
-  qt_test.boolean(true)
-want:
-  <same as "got">
+  []int{1, 2, 3}
+element:
+  int(4)
+want count:
+  int(0)
 `,
 }, {
-	about:   "IsTrue: failure with subtype",
-	checker: qt.IsTrue(boolean(false)),
+	about:   "SliceCount: failure",
+	checker: qt.SliceCount([]string{"error", "info", "error", "error"}, "error", 2),
 	expectedCheckFailure: `
 error:
-  values are not equal
+  unexpected number of occurrences
+count:
+  int(3)
 got:
-  qt_test.boolean(false)
-want:
-  qt_test.boolean(true)
+  []string{"error", "info", "error", "error"}
+element:
+  "error"
+want count:
+  int(2)
 `,
 }, {
-	about:   "IsFalse: success",
-	checker: qt.IsFalse(false),
+	about:   "SliceCount: negative count",
+	checker: qt.SliceCount([]int{1}, 1, -1),
+	expectedCheckFailure: `
+error:
+  bad check: negative count -1
+`,
 	expectedNegateFailure: `
 error:
-  unexpected success
-got:
-  bool(false)
-want:
-  <same as "got">
+  bad check: negative count -1
 `,
 }, {
-	about:   "IsFalse: failure",
-	checker: qt.IsFalse(true),
-	expectedCheckFailure: `
+	about:   "MappedEquals: success",
+	checker: qt.MappedEquals([]string{"a", "bb", "ccc"}, func(s string) int { return len(s) }, []int{1, 2, 3}),
+	expectedNegateFailure: `
 error:
-  values are not equal
+  unexpected success
 got:
-  bool(true)
+  []string{"a", "bb", "ccc"}
+mapping function:
+  func(string) int {...}
 want:
-  bool(false)
+  []int{1, 2, 3}
 `,
 }, {
-	about:   "StringContains match",
-	checker: qt.StringContains("hello, world", "world"),
-	expectedNegateFailure: `
+	about:   "MappedEquals: mismatch",
+	checker: qt.MappedEquals([]string{"a", "bb", "ccc"}, func(s string) int { return len(s) }, []int{1, 2, 4}),
+	expectedCheckFailure: fmt.Sprintf(`
 error:
-  unexpected success
-got:
-  "hello, world"
-substr:
-  "world"
+  mapped values are not deep equal
+first mismatch at index:
+  int(2)
+diff (-want +mapped):
+%s
+mapped:
+  []int{1, 2, 3}
+want:
+  []int{1, 2, 4}
+`, diff([]int{1, 2, 3}, []int{1, 2, 4})),
+}, {
+	about:   "MappedEquals: different lengths",
+	checker: qt.MappedEquals([]int{1, 2}, func(i int) int { return i * 10 }, []int{10, 20, 30}),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  mapped values are not deep equal
+first mismatch at index:
+  int(2)
+diff (-want +mapped):
+%s
+mapped:
+  []int{10, 20}
+want:
+  []int{10, 20, 30}
+`, diff([]int{10, 20}, []int{10, 20, 30})),
+}, {
+	about:   "MappedEquals: nil mapping function",
+	checker: qt.MappedEquals([]int{1}, (func(int) int)(nil), []int{1}),
+	expectedCheckFailure: `
+error:
+  bad check: nil mapping function provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: nil mapping function provided
 `,
 }, {
-	about:   "StringContains no match",
-	checker: qt.StringContains("hello, world", "worlds"),
+	about:   "SliceStartsWith: success",
+	checker: qt.SliceStartsWith([]string{"go", "test", "-v", "./..."}, []string{"go", "test"}),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  []string{"go", "test", "-v", "./..."}
+prefix:
+  []string{"go", "test"}
+`,
+}, {
+	about:   "SliceStartsWith: empty prefix",
+	checker: qt.SliceStartsWith([]int{1}, nil),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  []int{1}
+prefix:
+  []int(nil)
+`,
+}, {
+	about:   "SliceStartsWith: failure",
+	checker: qt.SliceStartsWith([]string{"go", "vet", "./..."}, []string{"go", "test"}),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  slice does not start with prefix
+diff (-prefix +got):
+%s
+got:
+  []string{"go", "vet", "./..."}
+prefix:
+  []string{"go", "test"}
+`, diff([]string{"go", "vet"}, []string{"go", "test"})),
+}, {
+	about:   "SliceStartsWith: got too short",
+	checker: qt.SliceStartsWith([]int{1}, []int{1, 2}),
 	expectedCheckFailure: `
 error:
-  no substring match found
+  slice is shorter than prefix
+len(got):
+  int(1)
+len(prefix):
+  int(2)
 got:
-  "hello, world"
-substr:
-  "worlds"
-`}, {
-	about:   "SliceContains match",
-	checker: qt.SliceContains([]string{"a", "b", "c"}, "a"),
+  []int{1}
+prefix:
+  []int{1, 2}
+`,
+}, {
+	about:   "SliceEndsWith: success",
+	checker: qt.SliceEndsWith([]int{1, 2, 3}, []int{2, 3}),
 	expectedNegateFailure: `
 error:
   unexpected success
-container:
-  []string{"a", "b", "c"}
+got:
+  []int{1, 2, 3}
+suffix:
+  []int{2, 3}
+`,
+}, {
+	about:   "SliceEndsWith: failure",
+	checker: qt.SliceEndsWith([]int{1, 2, 3}, []int{1, 2}),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  slice does not end with suffix
+diff (-suffix +got):
+%s
+got:
+  []int{1, 2, 3}
+suffix:
+  []int{1, 2}
+`, diff([]int{2, 3}, []int{1, 2})),
+}, {
+	about:   "SliceEndsWith: got too short",
+	checker: qt.SliceEndsWith([]int(nil), []int{1}),
+	expectedCheckFailure: `
+error:
+  slice is shorter than suffix
+len(got):
+  int(0)
+len(suffix):
+  int(1)
+got:
+  []int(nil)
+suffix:
+  []int{1}
+`,
+}, {
+	about:   "SliceEndsWith: struct elements",
+	checker: qt.SliceEndsWith([]cmpType{{}}, []cmpType{{}}),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  []qt_test.cmpType{
+      {},
+  }
+suffix:
+  <same as "got">
+`,
+}, {
+	about:   "IsSubsequence: success",
+	checker: qt.IsSubsequence([]string{"open", "write", "close"}, []string{"open", "read", "write", "flush", "close"}),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  []string{"open", "write", "close"}
 want:
-  "a"
+  []string{"open", "read", "write", "flush", "close"}
 `,
 }, {
-	about:   "SliceContains mismatch",
-	checker: qt.SliceContains([]string{"a", "b", "c"}, "d"),
+	about:   "IsSubsequence: empty got",
+	checker: qt.IsSubsequence(nil, []int{1, 2}),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  []int(nil)
+want:
+  []int{1, 2}
+`,
+}, {
+	about:   "IsSubsequence: wrong order",
+	checker: qt.IsSubsequence([]string{"open", "close", "write"}, []string{"open", "write", "close"}),
 	expectedCheckFailure: `
 error:
-  no matching element found
-container:
-  []string{"a", "b", "c"}
+  slice is not a subsequence
+unmatched element:
+  "write"
+index:
+  int(2)
+got:
+  []string{"open", "close", "write"}
 want:
-  "d"
+  []string{"open", "write", "close"}
 `,
 }, {
-	about: "Contains with map",
-	checker: qt.MapContains(map[string]string{
-		"a": "d",
-		"b": "a",
-	}, "d"),
+	about:   "IsSubsequence: repeated element",
+	checker: qt.IsSubsequence([]int{3, 3}, []int{3, 4}),
+	expectedCheckFailure: `
+error:
+  slice is not a subsequence
+unmatched element:
+  int(3)
+index:
+  int(1)
+got:
+  []int{3, 3}
+want:
+  []int{3, 4}
+`,
+}, {
+	about:   "LessFunc: less",
+	checker: qt.LessFunc("a", "b", strings.Compare),
 	expectedNegateFailure: `
 error:
   unexpected success
-container:
-  map[string]string{"a":"d", "b":"a"}
+got:
+  "a"
 want:
-  "d"
+  "b"
 `,
 }, {
-	about: "Contains with map and interface value",
-	checker: qt.MapContains(map[string]any{
-		"a": "d",
-		"b": "a",
-	}, "d"),
+	about:   "LessFunc: equal",
+	checker: qt.LessFunc(goTime, goTime, compareTimes),
+	expectedCheckFailure: `
+error:
+  got is not less than want
+compare result:
+  int(0)
+got:
+  s"2012-03-28 00:00:00 +0000 UTC"
+want:
+  <same as "got">
+`,
+}, {
+	about:   "GreaterFunc: greater",
+	checker: qt.GreaterFunc(goTime.Add(time.Hour), goTime, compareTimes),
 	expectedNegateFailure: `
 error:
   unexpected success
-container:
-  map[string]interface {}{
-      "a": "d",
-      "b": "a",
-  }
+got:
+  s"2012-03-28 01:00:00 +0000 UTC"
 want:
-  "d"
+  s"2012-03-28 00:00:00 +0000 UTC"
 `,
 }, {
-	about:   "All slice equals",
-	checker: qt.SliceAll([]string{"a", "a"}, qt.F2(qt.Equals[string], "a")),
+	about:   "GreaterFunc: less",
+	checker: qt.GreaterFunc("a", "b", strings.Compare),
+	expectedCheckFailure: `
+error:
+  got is not greater than want
+compare result:
+  int(-1)
+got:
+  "a"
+want:
+  "b"
+`,
+}, {
+	about:   "EqualFunc: equal",
+	checker: qt.EqualFunc(goTime, goTime.In(time.FixedZone("UTC+1", 3600)), compareTimes),
 	expectedNegateFailure: `
 error:
   unexpected success
-container:
-  []string{"a", "a"}
+got:
+  s"2012-03-28 00:00:00 +0000 UTC"
+want:
+  s"2012-03-28 01:00:00 +0100 UTC+1"
+`,
+}, {
+	about:   "EqualFunc: not equal",
+	checker: qt.EqualFunc("b", "a", strings.Compare),
+	expectedCheckFailure: `
+error:
+  got is not equal to want
+compare result:
+  int(1)
+got:
+  "b"
 want:
   "a"
 `,
 }, {
-	about:   "All slice match",
-	checker: qt.SliceAll([]string{"red", "blue", "green"}, qt.F2(qt.Matches[string], ".*e.*")),
+	about:   "EqualFunc: nil compare function",
+	checker: qt.EqualFunc("a", "a", nil),
+	expectedCheckFailure: `
+error:
+  bad check: nil compare function provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: nil compare function provided
+`,
+}, {
+	about:   "IsStrictlyIncreasing: success",
+	checker: qt.IsStrictlyIncreasing([]int{1, 2, 5, 10}),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  []int{1, 2, 5, 10}
+`,
+}, {
+	about:   "IsStrictlyIncreasing: empty slice",
+	checker: qt.IsStrictlyIncreasing([]string(nil)),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  []string(nil)
+`,
+}, {
+	about:   "IsStrictlyIncreasing: equal adjacent elements",
+	checker: qt.IsStrictlyIncreasing([]int{1, 2, 2, 3}),
+	expectedCheckFailure: `
+error:
+  slice is not strictly increasing
+indices:
+  []int{1, 2}
+elements:
+  []int{2, 2}
+got:
+  []int{1, 2, 2, 3}
+`,
+}, {
+	about:   "IsStrictlyIncreasing: going backwards",
+	checker: qt.IsStrictlyIncreasing([]float64{0.5, 1.5, 1.25}),
+	expectedCheckFailure: `
+error:
+  slice is not strictly increasing
+indices:
+  []int{1, 2}
+elements:
+  []float64{1.5, 1.25}
+got:
+  []float64{0.5, 1.5, 1.25}
+`,
+}, {
+	about:   "IsStrictlyDecreasing: success",
+	checker: qt.IsStrictlyDecreasing([]string{"c", "b", "a"}),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  []string{"c", "b", "a"}
+`,
+}, {
+	about:   "IsStrictlyDecreasing: failure",
+	checker: qt.IsStrictlyDecreasing([]uint{3, 3, 1}),
+	expectedCheckFailure: `
+error:
+  slice is not strictly decreasing
+indices:
+  []int{0, 1}
+elements:
+  []uint{0x3, 0x3}
+got:
+  []uint{0x3, 0x3, 0x1}
+`,
+}, {
+	about:   "Matches: match",
+	checker: qt.Matches("these are the voyages", "these are the .*"),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got value:
+  "these are the voyages"
+regexp:
+  "these are the .*"
+`,
+}, {
+	about:   "Matches: mismatch",
+	checker: qt.Matches("voyages", "these are the voyages"),
+	expectedCheckFailure: `
+error:
+  value does not match regexp
+got value:
+  "voyages"
+regexp:
+  "these are the voyages"
+`,
+}, {
+	about:   "Matches: empty pattern",
+	checker: qt.Matches("these are the voyages", ""),
+	expectedCheckFailure: `
+error:
+  value does not match regexp
+got value:
+  "these are the voyages"
+regexp:
+  ""
+`,
+}, {
+	about:   "Matches: complex pattern",
+	checker: qt.Matches("end of the universe", "bad wolf|end of the .*"),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got value:
+  "end of the universe"
+regexp:
+  "bad wolf|end of the .*"
+`,
+}, {
+	about:   "Matches: invalid pattern",
+	checker: qt.Matches("voyages", "("),
+	expectedCheckFailure: `
+error:
+  bad check: cannot compile regexp: error parsing regexp: missing closing ): ` + "`^(()$`" + `
+regexp:
+  "("
+`,
+	expectedNegateFailure: `
+error:
+  bad check: cannot compile regexp: error parsing regexp: missing closing ): ` + "`^(()$`" + `
+regexp:
+  "("
+`,
+}, {
+	about:   "Matches: match with pre-compiled regexp",
+	checker: qt.Matches("resistance is futile", regexp.MustCompile("resistance is (futile|useful)")),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got value:
+  "resistance is futile"
+regexp:
+  s"resistance is (futile|useful)"
+`,
+}, {
+	about:   "Matches: mismatch with pre-compiled regexp",
+	checker: qt.Matches("resistance is cool", regexp.MustCompile("resistance is (futile|useful)")),
+	expectedCheckFailure: `
+error:
+  value does not match regexp
+got value:
+  "resistance is cool"
+regexp:
+  s"resistance is (futile|useful)"
+`,
+}, {
+	about:   "Matches: match with pre-compiled multi-line regexp",
+	checker: qt.Matches("line 1\nline 2", regexp.MustCompile(`line \d\nline \d`)),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got value:
+  "line 1\nline 2"
+regexp:
+  s"line \\d\\nline \\d"
+`,
+}, {
+	about:   "MatchesPart: partial match",
+	checker: qt.MatchesPart("these are the voyages", "the v[a-z]+"),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got value:
+  "these are the voyages"
+regexp:
+  "the v[a-z]+"
+`,
+}, {
+	about:   "MatchesPart: mismatch",
+	checker: qt.MatchesPart("these are the voyages", "bad wolf"),
+	expectedCheckFailure: `
+error:
+  value does not match regexp
+got value:
+  "these are the voyages"
+regexp:
+  "bad wolf"
+`,
+}, {
+	about:   "MatchesPart: explicit anchors",
+	checker: qt.MatchesPart("these are the voyages", "^voyages"),
+	expectedCheckFailure: `
+error:
+  value does not match regexp
+got value:
+  "these are the voyages"
+regexp:
+  "^voyages"
+`,
+}, {
+	about:   "MatchesPart: invalid pattern",
+	checker: qt.MatchesPart("voyages", "("),
+	expectedCheckFailure: `
+error:
+  bad check: cannot compile regexp: error parsing regexp: missing closing ): ` + "`(`" + `
+regexp:
+  "("
+`,
+	expectedNegateFailure: `
+error:
+  bad check: cannot compile regexp: error parsing regexp: missing closing ): ` + "`(`" + `
+regexp:
+  "("
+`,
+}, {
+	about:   "MatchesPart: match with pre-compiled regexp",
+	checker: qt.MatchesPart("resistance is futile", regexp.MustCompile("is (futile|useful)")),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got value:
+  "resistance is futile"
+regexp:
+  s"is (futile|useful)"
+`,
+}, {
+	about:   "MatchesNamed: match",
+	checker: qt.MatchesNamed("ERROR disk full", regexp.MustCompile(`(?P<level>[A-Z]+) (?P<msg>.*)`), map[string]string{"level": "ERROR", "msg": "disk full"}),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got value:
+  "ERROR disk full"
+regexp:
+  s"(?P<level>[A-Z]+) (?P<msg>.*)"
+want groups:
+  map[string]string{"level":"ERROR", "msg":"disk full"}
+`,
+}, {
+	about:   "MatchesNamed: unnamed groups are ignored",
+	checker: qt.MatchesNamed("id=42;", regexp.MustCompile(`(id)=(?P<value>[0-9]+)(;)`), map[string]string{"value": "42"}),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got value:
+  "id=42;"
+regexp:
+  s"(id)=(?P<value>[0-9]+)(;)"
+want groups:
+  map[string]string{"value":"42"}
+`,
+}, {
+	about:   "MatchesNamed: groups mismatch",
+	checker: qt.MatchesNamed("WARN disk full", regexp.MustCompile(`(?P<level>[A-Z]+) (?P<msg>.*)`), map[string]string{"level": "ERROR", "msg": "disk full"}),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  named groups do not match
+named groups:
+  map[string]string{"level":"WARN", "msg":"disk full"}
+diff (-want +got):
+%s
+got value:
+  "WARN disk full"
+regexp:
+  s"(?P<level>[A-Z]+) (?P<msg>.*)"
+want groups:
+  map[string]string{"level":"ERROR", "msg":"disk full"}
+`, diff(map[string]string{"level": "WARN", "msg": "disk full"}, map[string]string{"level": "ERROR", "msg": "disk full"})),
+}, {
+	about:   "MatchesNamed: no match",
+	checker: qt.MatchesNamed("disk full", regexp.MustCompile(`(?P<level>[A-Z]+) (?P<msg>.*)`), nil),
+	expectedCheckFailure: `
+error:
+  value does not match regexp
+got value:
+  "disk full"
+regexp:
+  s"(?P<level>[A-Z]+) (?P<msg>.*)"
+want groups:
+  map[string]string{}
+`,
+}, {
+	about:   "MatchesNamed: nil regexp",
+	checker: qt.MatchesNamed("disk full", nil, nil),
+	expectedCheckFailure: `
+error:
+  bad check: nil regexp provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: nil regexp provided
+`,
+}, {
+	about:   "RegexpCompiles: valid",
+	checker: qt.RegexpCompiles(`^[a-z]+\d*$`),
+	expectedNegateFailure: `
+error:
+  unexpected success
+regexp:
+  "^[a-z]+\\d*$"
+`,
+}, {
+	about:   "RegexpCompiles: invalid",
+	checker: qt.RegexpCompiles("a(b"),
+	expectedCheckFailure: `
+error:
+  cannot compile regexp: error parsing regexp: missing closing ): ` + "`a(b`" + `
+regexp:
+  "a(b"
+`,
+}, {
+	about:   "RegexpInvalid: invalid",
+	checker: qt.RegexpInvalid("[z-a]"),
+	expectedNegateFailure: `
+error:
+  unexpected success
+compile error:
+  error parsing regexp: invalid character class range: ` + "`z-a`" + `
+regexp:
+  "[z-a]"
+`,
+}, {
+	about:   "RegexpInvalid: valid",
+	checker: qt.RegexpInvalid("a|b"),
+	expectedCheckFailure: `
+error:
+  regexp compiles successfully
+regexp:
+  "a|b"
+`,
+}, {
+	about:   "StringEquals: equal",
+	checker: qt.StringEquals(90*time.Second, "1m30s"),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got string:
+  "1m30s"
+want:
+  <same as "got string">
+`,
+}, {
+	about:   "StringEquals: not equal",
+	checker: qt.StringEquals(net.IPv4(10, 0, 0, 1), "10.0.0.2"),
+	expectedCheckFailure: `
+error:
+  String output is not equal
+got string:
+  "10.0.0.1"
+want:
+  "10.0.0.2"
+`,
+}, {
+	about:   "StringEquals: nil Stringer",
+	checker: qt.StringEquals(nil, ""),
+	expectedCheckFailure: `
+error:
+  bad check: nil Stringer provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: nil Stringer provided
+`,
+}, {
+	about:   "StringEquals: typed nil Stringer",
+	checker: qt.StringEquals((*nilStringer)(nil), ""),
+	expectedCheckFailure: `
+error:
+  bad check: String method panicked: runtime error: invalid memory address or nil pointer dereference
+`,
+	expectedNegateFailure: `
+error:
+  bad check: String method panicked: runtime error: invalid memory address or nil pointer dereference
+`,
+}, {
+	about:   "StringMatches: match",
+	checker: qt.StringMatches(90*time.Second, `\d+m\d+s`),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got string:
+  "1m30s"
+regexp:
+  "\\d+m\\d+s"
+`,
+}, {
+	about:   "StringMatches: mismatch",
+	checker: qt.StringMatches(net.IPv4(10, 0, 0, 1), regexp.MustCompile(`^192\.`)),
+	expectedCheckFailure: `
+error:
+  String output does not match regexp
+got string:
+  "10.0.0.1"
+regexp:
+  s"^192\\."
+`,
+}, {
+	about:   "ErrorMatches: perfect match",
+	checker: qt.ErrorMatches(errBadWolf, "bad wolf"),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got error:
+  bad wolf
+    file:line
+regexp:
+  "bad wolf"
+`,
+}, {
+	about:   "ErrorMatches: match",
+	checker: qt.ErrorMatches(errBadWolf, "bad .*"),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got error:
+  bad wolf
+    file:line
+regexp:
+  "bad .*"
+`,
+}, {
+	about:   "ErrorMatches: mismatch",
+	checker: qt.ErrorMatches(errBadWolf, "exterminate"),
+	expectedCheckFailure: `
+error:
+  error does not match regexp
+got error:
+  bad wolf
+    file:line
+regexp:
+  "exterminate"
+`,
+}, {
+	about:   "ErrorMatches: empty pattern",
+	checker: qt.ErrorMatches(errBadWolf, ""),
+	expectedCheckFailure: `
+error:
+  error does not match regexp
+got error:
+  bad wolf
+    file:line
+regexp:
+  ""
+`,
+}, {
+	about:   "ErrorMatches: complex pattern",
+	checker: qt.ErrorMatches(errBadWolf, "bad wolf|end of the universe"),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got error:
+  bad wolf
+    file:line
+regexp:
+  "bad wolf|end of the universe"
+`,
+}, {
+	about:   "ErrorMatches: invalid pattern",
+	checker: qt.ErrorMatches(errBadWolf, "("),
+	expectedCheckFailure: `
+error:
+  bad check: cannot compile regexp: error parsing regexp: missing closing ): ` + "`^(()$`" + `
+regexp:
+  "("
+`,
+	expectedNegateFailure: `
+error:
+  bad check: cannot compile regexp: error parsing regexp: missing closing ): ` + "`^(()$`" + `
+regexp:
+  "("
+`,
+}, {
+	about:   "ErrorMatches: nil error",
+	checker: qt.ErrorMatches(nil, "some pattern"),
+	expectedCheckFailure: `
+error:
+  got nil error but want non-nil
+got error:
+  nil
+regexp:
+  "some pattern"
+`,
+}, {
+	about:   "ErrorMatches: match with pre-compiled regexp",
+	checker: qt.ErrorMatches(errBadWolf, regexp.MustCompile("bad (wolf|dog)")),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got error:
+  bad wolf
+    file:line
+regexp:
+  s"bad (wolf|dog)"
+`,
+}, {
+	about:   "ErrorMatches: match with pre-compiled multi-line regexp",
+	checker: qt.ErrorMatches(errBadWolfMultiLine, regexp.MustCompile(`bad (wolf|dog)\nfaulty (logic|statement)`)),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got error:
+  bad wolf
+  faulty logic
+    file:line
+regexp:
+  s"bad (wolf|dog)\\nfaulty (logic|statement)"
+`,
+}, {
+	about:   "ErrorMatches: mismatch with pre-compiled regexp",
+	checker: qt.ErrorMatches(errBadWolf, regexp.MustCompile("good (wolf|dog)")),
+	expectedCheckFailure: `
+error:
+  error does not match regexp
+got error:
+  bad wolf
+    file:line
+regexp:
+  s"good (wolf|dog)"
+`,
+}, {
+	about:   "ErrorHasMessage: match",
+	checker: qt.ErrorHasMessage(errors.New("bad wolf (again)."), "bad wolf (again)."),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got error:
+  e"bad wolf (again)."
+want message:
+  "bad wolf (again)."
+`,
+}, {
+	about:   "ErrorHasMessage: mismatch",
+	checker: qt.ErrorHasMessage(errBadWolf, "bad wolf."),
+	expectedCheckFailure: `
+error:
+  error message is not equal
+got error:
+  bad wolf
+    file:line
+want message:
+  "bad wolf."
+`,
+}, {
+	about:   "ErrorHasMessage: multi-line mismatch",
+	checker: qt.ErrorHasMessage(errors.New("bad wolf\nfaulty logic"), "bad wolf\ngood logic"),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  error message is not equal
+line diff (-want +got):
+%s
+got error:
+  e"bad wolf\nfaulty logic"
+want message:
+  "bad wolf\ngood logic"
+`, diff([]string{"bad wolf\n", "faulty logic"}, []string{"bad wolf\n", "good logic"})),
+}, {
+	about:   "ErrorHasMessage: nil error",
+	checker: qt.ErrorHasMessage(nil, "bad wolf"),
+	expectedCheckFailure: `
+error:
+  got nil error but want non-nil
+got error:
+  nil
+want message:
+  "bad wolf"
+`,
+}, {
+	about:   "ErrorDoesNotContain: substring not found",
+	checker: qt.ErrorDoesNotContain(errors.New("cannot open file"), "/etc/secret"),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got error:
+  e"cannot open file"
+substr:
+  "/etc/secret"
+`,
+}, {
+	about:   "ErrorDoesNotContain: substring found",
+	checker: qt.ErrorDoesNotContain(errors.New("cannot open /etc/secret: permission denied"), "/etc/secret"),
+	expectedCheckFailure: `
+error:
+  error message contains substring
+found at offset:
+  int(12)
+got error:
+  e"cannot open /etc/secret: permission denied"
+substr:
+  "/etc/secret"
+`,
+}, {
+	about:   "ErrorDoesNotContain: nil error",
+	checker: qt.ErrorDoesNotContain(nil, "/etc/secret"),
+	expectedCheckFailure: `
+error:
+  got nil error but want non-nil
+got error:
+  nil
+substr:
+  "/etc/secret"
+`,
+}, {
+	about:   "ErrorDoesNotContain: empty substring",
+	checker: qt.ErrorDoesNotContain(errors.New("bad wolf"), ""),
+	expectedCheckFailure: `
+error:
+  bad check: empty substring
+`,
+	expectedNegateFailure: `
+error:
+  bad check: empty substring
+`,
+}, {
+	about:   "ErrorChainMatches: match at top level",
+	checker: qt.ErrorChainMatches(errors.New("bad wolf"), "bad wolf"),
+	expectedNegateFailure: `
+error:
+  unexpected success
+matched at depth:
+  int(0)
+got error:
+  e"bad wolf"
+regexp:
+  "bad wolf"
+`,
+}, {
+	about:   "ErrorChainMatches: match in wrapped error",
+	checker: qt.ErrorChainMatches(fmt.Errorf("middleware: %w", fmt.Errorf("handler: %w", errors.New("bad wolf"))), "bad (wolf|dog)"),
+	expectedNegateFailure: `
+error:
+  unexpected success
+matched at depth:
+  int(2)
+got error:
+  e"middleware: handler: bad wolf"
+regexp:
+  "bad (wolf|dog)"
+`,
+}, {
+	about:   "ErrorChainMatches: match with pre-compiled regexp",
+	checker: qt.ErrorChainMatches(fmt.Errorf("middleware: %w", errors.New("bad wolf")), regexp.MustCompile("^bad")),
+	expectedNegateFailure: `
+error:
+  unexpected success
+matched at depth:
+  int(1)
+got error:
+  e"middleware: bad wolf"
+regexp:
+  s"^bad"
+`,
+}, {
+	about:   "ErrorChainMatches: mismatch",
+	checker: qt.ErrorChainMatches(fmt.Errorf("middleware: %w", errors.New("bad wolf")), "bad dog"),
+	expectedCheckFailure: `
+error:
+  no error in chain matches regexp
+error chain:
+  depth 0: "middleware: bad wolf"
+  depth 1: "bad wolf"
+got error:
+  e"middleware: bad wolf"
+regexp:
+  "bad dog"
+`,
+}, {
+	about:   "ErrorChainMatches: nil error",
+	checker: qt.ErrorChainMatches(nil, "bad wolf"),
+	expectedCheckFailure: `
+error:
+  got nil error but want non-nil
+got error:
+  nil
+regexp:
+  "bad wolf"
+`,
+}, {
+	about:   "ErrorChainMatches: invalid pattern",
+	checker: qt.ErrorChainMatches(errBadWolf, "("),
+	expectedCheckFailure: `
+error:
+  bad check: cannot compile regexp: error parsing regexp: missing closing ): ` + "`^(()$`" + `
+regexp:
+  "("
+`,
+	expectedNegateFailure: `
+error:
+  bad check: cannot compile regexp: error parsing regexp: missing closing ): ` + "`^(()$`" + `
+regexp:
+  "("
+`,
+}, {
+	about: "ErrorChainTypes: match",
+	checker: qt.ErrorChainTypes(fmt.Errorf("wrapped: %w", targetErr),
+		reflect.TypeOf(fmt.Errorf("%w", targetErr)),
+		reflect.TypeOf(targetErr),
+	),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got error:
+  e"wrapped: ptr: target"
+want types:
+  depth 0: *fmt.wrapError
+  depth 1: *qt_test.errTarget
+`,
+}, {
+	about: "ErrorChainTypes: wrong order",
+	checker: qt.ErrorChainTypes(fmt.Errorf("wrapped: %w", targetErr),
+		reflect.TypeOf(targetErr),
+		reflect.TypeOf(fmt.Errorf("%w", targetErr)),
+	),
+	expectedCheckFailure: `
+error:
+  error chain types do not match
+got types:
+  depth 0: *fmt.wrapError
+  depth 1: *qt_test.errTarget
+got error:
+  e"wrapped: ptr: target"
+want types:
+  depth 0: *qt_test.errTarget
+  depth 1: *fmt.wrapError
+`,
+}, {
+	about:   "ErrorChainTypes: shorter chain",
+	checker: qt.ErrorChainTypes(targetErr, reflect.TypeOf(targetErr), reflect.TypeOf(targetErr)),
+	expectedCheckFailure: `
+error:
+  error chain types do not match
+got types:
+  depth 0: *qt_test.errTarget
+got error:
+  e"ptr: target"
+want types:
+  depth 0: *qt_test.errTarget
+  depth 1: *qt_test.errTarget
+`,
+}, {
+	about:   "ErrorChainTypes: nil error",
+	checker: qt.ErrorChainTypes(nil, reflect.TypeOf(targetErr)),
+	expectedCheckFailure: `
+error:
+  got nil error but want non-nil
+got error:
+  nil
+want types:
+  depth 0: *qt_test.errTarget
+`,
+}, {
+	about:   "ErrorChainTypes: no types",
+	checker: qt.ErrorChainTypes(targetErr),
+	expectedCheckFailure: `
+error:
+  bad check: no types provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: no types provided
+`,
+}, {
+	about:   "ErrorChainTypes: nil type",
+	checker: qt.ErrorChainTypes(targetErr, nil),
+	expectedCheckFailure: `
+error:
+  bad check: nil type provided at index 0
+`,
+	expectedNegateFailure: `
+error:
+  bad check: nil type provided at index 0
+`,
+}, {
+	about:   "PanicMatches: perfect match",
+	checker: qt.PanicMatches(func() { panic("error: bad wolf") }, "error: bad wolf"),
+	expectedNegateFailure: `
+error:
+  unexpected success
+panic value:
+  "error: bad wolf"
+function:
+  func() {...}
+regexp:
+  <same as "panic value">
+`,
+}, {
+	about:   "PanicMatches: match",
+	checker: qt.PanicMatches(func() { panic("error: bad wolf") }, "error: .*"),
+	expectedNegateFailure: `
+error:
+  unexpected success
+panic value:
+  "error: bad wolf"
+function:
+  func() {...}
+regexp:
+  "error: .*"
+`,
+}, {
+	about:   "PanicMatches: mismatch",
+	checker: qt.PanicMatches(func() { panic("error: bad wolf") }, "error: exterminate"),
+	expectedCheckFailure: `
+error:
+  panic value does not match regexp
+panic value:
+  "error: bad wolf"
+function:
+  func() {...}
+regexp:
+  "error: exterminate"
+`,
+}, {
+	about:   "PanicMatches: empty pattern",
+	checker: qt.PanicMatches(func() { panic("error: bad wolf") }, ""),
+	expectedCheckFailure: `
+error:
+  panic value does not match regexp
+panic value:
+  "error: bad wolf"
+function:
+  func() {...}
+regexp:
+  ""
+`,
+}, {
+	about:   "PanicMatches: complex pattern",
+	checker: qt.PanicMatches(func() { panic("bad wolf") }, "bad wolf|end of the universe"),
+	expectedNegateFailure: `
+error:
+  unexpected success
+panic value:
+  "bad wolf"
+function:
+  func() {...}
+regexp:
+  "bad wolf|end of the universe"
+`,
+}, {
+	about:   "PanicMatches: invalid pattern",
+	checker: qt.PanicMatches(func() { panic("error: bad wolf") }, "("),
+	expectedCheckFailure: `
+error:
+  bad check: cannot compile regexp: error parsing regexp: missing closing ): ` + "`^(()$`" + `
+panic value:
+  "error: bad wolf"
+regexp:
+  "("
+`,
+	expectedNegateFailure: `
+error:
+  bad check: cannot compile regexp: error parsing regexp: missing closing ): ` + "`^(()$`" + `
+panic value:
+  "error: bad wolf"
+regexp:
+  "("
+`,
+}, {
+	about:   "PanicMatches: no panic",
+	checker: qt.PanicMatches(func() {}, ".*"),
+	expectedCheckFailure: `
+error:
+  function did not panic
+function:
+  func() {...}
+regexp:
+  ".*"
+`,
+}, {
+	about:   "PanicMatches: match with pre-compiled regexp",
+	checker: qt.PanicMatches(func() { panic("error: bad wolf") }, regexp.MustCompile("error: bad (wolf|dog)")),
+	expectedNegateFailure: `
+error:
+  unexpected success
+panic value:
+  "error: bad wolf"
+function:
+  func() {...}
+regexp:
+  s"error: bad (wolf|dog)"
+`,
+}, {
+	about:   "PanicMatches: match with pre-compiled multi-line regexp",
+	checker: qt.PanicMatches(func() { panic("error: bad wolf\nfaulty logic") }, regexp.MustCompile(`error: bad (wolf|dog)\nfaulty (logic|statement)`)),
+	expectedNegateFailure: `
+error:
+  unexpected success
+panic value:
+  "error: bad wolf\nfaulty logic"
+function:
+  func() {...}
+regexp:
+  s"error: bad (wolf|dog)\\nfaulty (logic|statement)"
+`,
+}, {
+	about:   "PanicMatches: mismatch with pre-compiled regexp",
+	checker: qt.PanicMatches(func() { panic("error: bad wolf") }, regexp.MustCompile("good (wolf|dog)")),
+	expectedCheckFailure: `
+error:
+  panic value does not match regexp
+panic value:
+  "error: bad wolf"
+function:
+  func() {...}
+regexp:
+  s"good (wolf|dog)"
+`,
+}, {
+	about:   "IsNil: nil",
+	checker: qt.IsNil(any(nil)),
+	expectedNegateFailure: `
+error:
+  got <nil> but want non-nil
+got:
+  nil
+`,
+}, {
+	about:   "IsNil: nil pointer to struct",
+	checker: qt.IsNil((*struct{})(nil)),
+	expectedNegateFailure: `
+error:
+  got nil ptr but want non-nil
+got:
+  (*struct {})(nil)
+`,
+}, {
+	about:   "IsNil: nil func",
+	checker: qt.IsNil((func())(nil)),
+	expectedNegateFailure: `
+error:
+  got nil func but want non-nil
+got:
+  func() {...}
+`,
+}, {
+	about:   "IsNil: nil map",
+	checker: qt.IsNil((map[string]string)(nil)),
+	expectedNegateFailure: `
+error:
+  got nil map but want non-nil
+got:
+  map[string]string{}
+`,
+}, {
+	about:   "IsNil: nil slice",
+	checker: qt.IsNil(([]int)(nil)),
+	expectedNegateFailure: `
+error:
+  got nil slice but want non-nil
+got:
+  []int(nil)
+`,
+}, {
+	about:   "IsNil: nil error-implementing type",
+	checker: qt.IsNil(error((*errTest)(nil))),
+	expectedCheckFailure: `
+error:
+  got non-nil value
+note:
+  value is a non-nil interface wrapping a nil *qt_test.errTest
+got:
+  e(*qt_test.errTest)(nil)
+`,
+}, {
+	about:   "IsNil: nil pointer in interface",
+	checker: qt.IsNil(any((*int)(nil))),
+	expectedCheckFailure: `
+error:
+  got non-nil value
+note:
+  value is a non-nil interface wrapping a nil *int
+got:
+  (*int)(nil)
+`,
+}, {
+	about:   "IsNil: not nil",
+	checker: qt.IsNil([]int{}),
+	expectedCheckFailure: `
+error:
+  got non-nil value
+got:
+  []int{}
+`,
+}, {
+	about:   "IsNil: error is not nil",
+	checker: qt.IsNil(error(errBadWolf)),
+	expectedCheckFailure: `
+error:
+  got non-nil value
+got:
+  bad wolf
+    file:line
+`,
+}, {
+	about:   "IsNotNil: success",
+	checker: qt.IsNotNil(any(42)),
+	expectedNegateFailure: `
+error:
+  got non-nil value
+got:
+  int(42)
+`,
+}, {
+	about:   "IsNotNil: failure",
+	checker: qt.IsNotNil[any](nil),
+	expectedCheckFailure: `
+error:
+  got <nil> but want non-nil
+got:
+  nil
+`,
+}, {
+	about:   "IsNilSlice: nil",
+	checker: qt.IsNilSlice([]int(nil)),
+	expectedNegateFailure: `
+error:
+  got nil slice but want non-nil
+got:
+  []int(nil)
+`,
+}, {
+	about:   "IsNilSlice: empty",
+	checker: qt.IsNilSlice([]int{}),
+	expectedCheckFailure: `
+error:
+  slice is non-nil (it is an empty slice)
+got:
+  []int{}
+`,
+}, {
+	about:   "IsNilSlice: not empty",
+	checker: qt.IsNilSlice([]string{"a", "b"}),
+	expectedCheckFailure: `
+error:
+  slice is non-nil (it has length 2)
+got:
+  []string{"a", "b"}
+`,
+}, {
+	about:   "IsNilMap: nil",
+	checker: qt.IsNilMap(map[string]int(nil)),
+	expectedNegateFailure: `
+error:
+  got nil map but want non-nil
+got:
+  map[string]int{}
+`,
+}, {
+	about:   "IsNilMap: empty",
+	checker: qt.IsNilMap(map[string]int{}),
+	expectedCheckFailure: `
+error:
+  map is non-nil (it is an empty map)
+got:
+  map[string]int{}
+`,
+}, {
+	about:   "HasLen: arrays with the same length",
+	checker: qt.HasLen([4]string{"these", "are", "the", "voyages"}, 4),
+	expectedNegateFailure: `
+error:
+  unexpected success
+len(got):
+  int(4)
+got:
+  [4]string{"these", "are", "the", "voyages"}
+want length:
+  <same as "len(got)">
+`,
+}, {
+	about:   "HasLen: pointers to array with the same length",
+	checker: qt.HasLen(&[4]string{"these", "are", "the", "voyages"}, 4),
+	expectedNegateFailure: `
+error:
+  unexpected success
+len(got):
+  int(4)
+got:
+  &[4]string{"these", "are", "the", "voyages"}
+want length:
+  <same as "len(got)">
+`,
+}, {
+	about:   "HasLen: channels with the same length",
+	checker: qt.HasLen(chInt, 2),
+	expectedNegateFailure: fmt.Sprintf(`
+error:
+  unexpected success
+len(got):
+  int(2)
+got:
+  (chan int)(%v)
+want length:
+  <same as "len(got)">
+`, chInt),
+}, {
+	about:   "HasLen: maps with the same length",
+	checker: qt.HasLen(map[string]bool{"true": true}, 1),
+	expectedNegateFailure: `
+error:
+  unexpected success
+len(got):
+  int(1)
+got:
+  map[string]bool{"true":true}
+want length:
+  <same as "len(got)">
+`,
+}, {
+	about:   "HasLen: slices with the same length",
+	checker: qt.HasLen([]int{}, 0),
+	expectedNegateFailure: `
+error:
+  unexpected success
+len(got):
+  int(0)
+got:
+  []int{}
+want length:
+  <same as "len(got)">
+`,
+}, {
+	about:   "HasLen: strings with the same length",
+	checker: qt.HasLen("these are the voyages", 21),
+	expectedNegateFailure: `
+error:
+  unexpected success
+len(got):
+  int(21)
+got:
+  "these are the voyages"
+want length:
+  <same as "len(got)">
+`,
+}, {
+	about:   "HasLen: arrays with different lengths",
+	checker: qt.HasLen([4]string{"these", "are", "the", "voyages"}, 0),
+	expectedCheckFailure: `
+error:
+  unexpected length
+len(got):
+  int(4)
+got:
+  [4]string{"these", "are", "the", "voyages"}
+want length:
+  int(0)
+`,
+}, {
+	about:   "HasLen: channels with different lengths",
+	checker: qt.HasLen(chInt, 4),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  unexpected length
+len(got):
+  int(2)
+got:
+  (chan int)(%v)
+want length:
+  int(4)
+`, chInt),
+}, {
+	about:   "HasLen: maps with different lengths",
+	checker: qt.HasLen(map[string]bool{"true": true}, 42),
+	expectedCheckFailure: `
+error:
+  unexpected length
+len(got):
+  int(1)
+got:
+  map[string]bool{"true":true}
+want length:
+  int(42)
+`,
+}, {
+	about:   "HasLen: slices with different lengths",
+	checker: qt.HasLen([]int{42, 47}, 1),
+	expectedCheckFailure: `
+error:
+  unexpected length
+len(got):
+  int(2)
+got:
+  []int{42, 47}
+want length:
+  int(1)
+`,
+}, {
+	about:   "HasLen: strings with different lengths",
+	checker: qt.HasLen("these are the voyages", 42),
+	expectedCheckFailure: `
+error:
+  unexpected length
+len(got):
+  int(21)
+got:
+  "these are the voyages"
+want length:
+  int(42)
+`,
+}, {
+	about:   "HasLen: value without a length",
+	checker: qt.HasLen(42, 42),
+	expectedCheckFailure: `
+error:
+  bad check: first argument of type int has no length
+got:
+  int(42)
+`,
+	expectedNegateFailure: `
+error:
+  bad check: first argument of type int has no length
+got:
+  int(42)
+`,
+}, {
+	about:   "HasLen: pointer value without a length",
+	checker: qt.HasLen(&[]string{"arrays", "are", "fine", "but", "not", "slices"}, 1),
+	expectedCheckFailure: `
+error:
+  bad check: first argument of type *[]string has no length
+got:
+  &[]string{"arrays", "are", "fine", "but", "not", "slices"}
+`,
+	expectedNegateFailure: `
+error:
+  bad check: first argument of type *[]string has no length
+got:
+  &[]string{"arrays", "are", "fine", "but", "not", "slices"}
+`,
+}, {
+	about:   "HasLen: interface value with the same length",
+	checker: qt.HasLen(any([]int{42, 47}), 2),
+	expectedNegateFailure: `
+error:
+  unexpected success
+len(got):
+  int(2)
+got:
+  []int{42, 47}
+want length:
+  <same as "len(got)">
+`,
+}, {
+	about:   "HasLen: interface value with different length",
+	checker: qt.HasLen(any(map[string]int{"a": 1}), 2),
+	expectedCheckFailure: `
+error:
+  unexpected length
+len(got):
+  int(1)
+got:
+  map[string]int{"a":1}
+want length:
+  int(2)
+`,
+}, {
+	about:   "HasLen: interface value holding a pointer to array",
+	checker: qt.HasLen(any(&[2]int{}), 2),
+	expectedNegateFailure: `
+error:
+  unexpected success
+len(got):
+  int(2)
+got:
+  &[2]int{0, 0}
+want length:
+  <same as "len(got)">
+`,
+}, {
+	about:   "HasLen: interface value without a length",
+	checker: qt.HasLen(any(42), 1),
+	expectedCheckFailure: `
+error:
+  bad check: first argument of type int has no length
+got:
+  int(42)
+`,
+	expectedNegateFailure: `
+error:
+  bad check: first argument of type int has no length
+got:
+  int(42)
+`,
+}, {
+	about:   "HasLen: nil interface value",
+	checker: qt.HasLen[any](nil, 0),
+	expectedCheckFailure: `
+error:
+  bad check: first argument of type interface {} is nil and has no length
+got:
+  nil
+`,
+	expectedNegateFailure: `
+error:
+  bad check: first argument of type interface {} is nil and has no length
+got:
+  nil
+`,
+}, {
+	about:   "ChannelLen: expected length",
+	checker: qt.ChannelLen(chInt, 2),
+	expectedNegateFailure: `
+error:
+  unexpected success
+length:
+  int(2)
+capacity:
+  int(4)
+`,
+}, {
+	about:   "ChannelLen: receive-only channel",
+	checker: qt.ChannelLen((<-chan int)(chInt), 2),
+	expectedNegateFailure: `
+error:
+  unexpected success
+length:
+  int(2)
+capacity:
+  int(4)
+`,
+}, {
+	about:   "ChannelLen: unexpected length",
+	checker: qt.ChannelLen(chInt, 4),
+	expectedCheckFailure: `
+error:
+  channel has 2 buffered items, want 4
+length:
+  int(2)
+capacity:
+  int(4)
+`,
+}, {
+	about:   "ChannelLen: invalid length",
+	checker: qt.ChannelLen(chInt, -1),
+	expectedCheckFailure: `
+error:
+  bad check: invalid length -1
+`,
+	expectedNegateFailure: `
+error:
+  bad check: invalid length -1
+`,
+}, {
+	about:   "ChannelEmpty: nil channel",
+	checker: qt.ChannelEmpty[int](nil),
+	expectedNegateFailure: `
+error:
+  unexpected success
+length:
+  int(0)
+capacity:
+  <same as "length">
+`,
+}, {
+	about:   "ChannelEmpty: not empty",
+	checker: qt.ChannelEmpty(chInt),
+	expectedCheckFailure: `
+error:
+  channel has 2 buffered items, want 0
+length:
+  int(2)
+capacity:
+  int(4)
+`,
+}, {
+	about: "ChannelFull: full",
+	checker: qt.ChannelFull(func() chan bool {
+		ch := make(chan bool, 1)
+		ch <- true
+		return ch
+	}()),
+	expectedNegateFailure: `
+error:
+  unexpected success
+length:
+  int(1)
+capacity:
+  <same as "length">
+`,
+}, {
+	about:   "ChannelFull: not full",
+	checker: qt.ChannelFull(chInt),
+	expectedCheckFailure: `
+error:
+  channel is not full: it has 2 buffered items, want 4
+length:
+  int(2)
+capacity:
+  int(4)
+`,
+}, {
+	about:   "ChannelFull: unbuffered channel",
+	checker: qt.ChannelFull(make(chan int)),
+	expectedCheckFailure: `
+error:
+  bad check: unbuffered channel provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: unbuffered channel provided
+`,
+}, {
+	about:   "Implements: implements interface",
+	checker: qt.Implements[error](errBadWolf),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  bad wolf
+    file:line
+want interface:
+  error
+`,
+}, {
+	about:   "Implements: does not implement interface",
+	checker: qt.Implements[Fooer](errBadWolf),
+	expectedCheckFailure: `
+error:
+  got value does not implement wanted interface
+missing methods:
+  Foo(): missing
+got:
+  bad wolf
+    file:line
+want interface:
+  qt_test.Fooer
+`,
+}, {
+	about:   "Implements: missing and mismatched methods",
+	checker: qt.Implements[fooBarer](partialFooBarer{}),
+	expectedCheckFailure: `
+error:
+  got value does not implement wanted interface
+missing methods:
+  Bar(int) string: has wrong signature Bar(string) string
+  Baz(): defined on pointer receiver
+  Foo(): missing
+got:
+  qt_test.partialFooBarer{}
+want interface:
+  qt_test.fooBarer
+`,
+}, {
+	about:   "Implements: pointer implements interface",
+	checker: qt.Implements[interface{ Baz() }](&partialFooBarer{}),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  &qt_test.partialFooBarer{}
+want interface:
+  interface { Baz() }
+`,
+}, {
+	about:   "Implements: fails if got nil",
+	checker: qt.Implements[Fooer](nil),
+	expectedCheckFailure: `
+error:
+  got nil value but want non-nil
+got:
+  nil
+`,
+}, {
+	about:   "ImplementsAll: implements all interfaces",
+	checker: qt.ImplementsAll(errBadWolf, reflect.TypeOf((*error)(nil)).Elem(), reflect.TypeOf((*fmt.Formatter)(nil)).Elem()),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  bad wolf
+    file:line
+want interfaces:
+  error
+  fmt.Formatter
+`,
+}, {
+	about:   "ImplementsAll: does not implement some interfaces",
+	checker: qt.ImplementsAll(partialFooBarer{}, reflect.TypeOf((*Fooer)(nil)).Elem(), reflect.TypeOf((*interface{ Bar(string) string })(nil)).Elem(), reflect.TypeOf((*fooBarer)(nil)).Elem()),
+	expectedCheckFailure: `
+error:
+  got value does not implement all wanted interfaces
+missing interfaces:
+  qt_test.Fooer
+    Foo(): missing
+  qt_test.fooBarer
+    Bar(int) string: has wrong signature Bar(string) string
+    Baz(): defined on pointer receiver
+    Foo(): missing
+got:
+  qt_test.partialFooBarer{}
+want interfaces:
+  qt_test.Fooer
+  interface { Bar(string) string }
+  qt_test.fooBarer
+`,
+}, {
+	about:   "ImplementsAll: fails if got nil",
+	checker: qt.ImplementsAll(nil, reflect.TypeOf((*Fooer)(nil)).Elem()),
+	expectedCheckFailure: `
+error:
+  got nil value but want non-nil
+got:
+  nil
+`,
+}, {
+	about:   "ImplementsAll: no interfaces provided",
+	checker: qt.ImplementsAll(errBadWolf),
+	expectedCheckFailure: `
+error:
+  bad check: no interfaces provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: no interfaces provided
+`,
+}, {
+	about:   "ImplementsAll: concrete type provided",
+	checker: qt.ImplementsAll(errBadWolf, reflect.TypeOf((*error)(nil)).Elem(), reflect.TypeOf(0)),
+	expectedCheckFailure: `
+error:
+  bad check: want an interface type but a concrete type was provided at index 1
+want interface:
+  int
+`,
+	expectedNegateFailure: `
+error:
+  bad check: want an interface type but a concrete type was provided at index 1
+want interface:
+  int
+`,
+}, {
+	about:   "StatusCode: expected status",
+	checker: qt.StatusCode(newResponse(http.StatusOK, "ok"), http.StatusOK),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got status:
+  "200 OK"
+want status:
+  int(200)
+`,
+}, {
+	about:   "StatusCode: unexpected status",
+	checker: qt.StatusCode(newResponse(http.StatusInternalServerError, "database unavailable"), http.StatusOK),
+	expectedCheckFailure: `
+error:
+  unexpected status: got 500 want 200
+body:
+  database unavailable
+got status:
+  "500 Internal Server Error"
+want status:
+  int(200)
+`,
+}, {
+	about:   "StatusCode: nil response",
+	checker: qt.StatusCode(nil, http.StatusOK),
+	expectedCheckFailure: `
+error:
+  bad check: nil response provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: nil response provided
+`,
+}, {
+	about:   "HeaderEquals: expected value",
+	checker: qt.HeaderEquals(newResponse(http.StatusOK, "", "Content-Type", "text/plain"), "content-type", "text/plain"),
+	expectedNegateFailure: `
+error:
+  unexpected success
+header:
+  "content-type"
+got:
+  "text/plain"
+want:
+  <same as "got">
+`,
+}, {
+	about:   "HeaderEquals: unexpected value",
+	checker: qt.HeaderEquals(newResponse(http.StatusOK, "", "Content-Type", "text/html", "X-Request-Id", "42"), "Content-Type", "application/json"),
+	expectedCheckFailure: `
+error:
+  unexpected header value
+headers:
+  Content-Type: text/html
+  X-Request-Id: 42
+header:
+  "Content-Type"
+got:
+  "text/html"
+want:
+  "application/json"
+`,
+}, {
+	about:   "HeaderEquals: header not found",
+	checker: qt.HeaderEquals(newResponse(http.StatusOK, "", "X-Request-Id", "42"), "Content-Type", "application/json"),
+	expectedCheckFailure: `
+error:
+  header "Content-Type" not found
+headers:
+  X-Request-Id: 42
+header:
+  "Content-Type"
+got:
+  ""
+want:
+  "application/json"
+`,
+}, {
+	about:   "BodyMatches: match",
+	checker: qt.BodyMatches(newResponse(http.StatusOK, `{"id": 42}`), `\{"id": \d+\}`),
+	expectedNegateFailure: tilde2bq(`
+error:
+  unexpected success
+body:
+  {"id": 42}
+pattern:
+  ~\{"id": \d+\}~
+`),
+}, {
+	about:   "BodyMatches: mismatch",
+	checker: qt.BodyMatches(newResponse(http.StatusOK, "not found"), regexp.MustCompile("^found$")),
+	expectedCheckFailure: `
+error:
+  body does not match pattern
+body:
+  not found
+pattern:
+  s"^found$"
+`,
+}, {
+	about:   "Satisfies: success with an error",
+	checker: qt.Satisfies(qt.BadCheckf("bad wolf"), qt.IsBadCheck),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  e"bad check: bad wolf"
+predicate:
+  func(error) bool {...}
+`,
+}, {
+	about:   "Satisfies: success with an int",
+	checker: qt.Satisfies(42, func(v int) bool { return v == 42 }),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  int(42)
+predicate:
+  func(int) bool {...}
+`,
+}, {
+	about:   "Satisfies: success with nil",
+	checker: qt.Satisfies([]int(nil), func(v []int) bool { return true }),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  []int(nil)
+predicate:
+  func([]int) bool {...}
+`,
+}, {
+	about:   "Satisfies: failure with an error",
+	checker: qt.Satisfies(nil, qt.IsBadCheck),
+	expectedCheckFailure: `
+error:
+  value does not satisfy predicate function
+got:
+  nil
+predicate:
+  func(error) bool {...}
+`,
+}, {
+	about:   "Satisfies: failure with a string",
+	checker: qt.Satisfies("bad wolf", func(string) bool { return false }),
+	expectedCheckFailure: `
+error:
+  value does not satisfy predicate function
+got:
+  "bad wolf"
+predicate:
+  func(string) bool {...}
+`,
+}, {
+	about:   "SatisfiesErr: success",
+	checker: qt.SatisfiesErr(42, func(v int) error { return nil }),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  int(42)
+predicate:
+  func(int) error {...}
+`,
+}, {
+	about: "SatisfiesErr: failure",
+	checker: qt.SatisfiesErr(-1, func(v int) error {
+		if v < 0 {
+			return fmt.Errorf("%d is negative", v)
+		}
+		return nil
+	}),
+	expectedCheckFailure: `
+error:
+  value does not satisfy predicate function: -1 is negative
+got:
+  int(-1)
+predicate:
+  func(int) error {...}
+`,
+}, {
+	about: "Changes: success",
+	checker: func() qt.Checker {
+		var name string
+		return qt.Changes(&name, func() { name = "bob" }, "bob")
+	}(),
+	expectedNegateFailure: `
+error:
+  unexpected success
+before:
+  "bob"
+after:
+  <same as "before">
+want:
+  <same as "before">
+`,
+}, {
+	about: "Changes: failure",
+	checker: func() qt.Checker {
+		tags := []string{"a"}
+		return qt.Changes(&tags, func() { tags = append(tags[:1:1], "c") }, []string{"a", "b"})
+	}(),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  value did not change as expected
+before:
+  []string{"a"}
+diff (-want +after):
+%s
+after:
+  []string{"a", "c"}
+want:
+  []string{"a", "b"}
+`, diff([]string{"a", "c"}, []string{"a", "b"})),
+}, {
+	about: "Changes: map changed in place",
+	checker: func() qt.Checker {
+		m := map[string]int{"a": 1}
+		return qt.Changes(&m, func() { m["a"] = 2 }, map[string]int{"a": 3})
+	}(),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  value did not change as expected
+before:
+  map[string]int{"a":1}
+diff (-want +after):
+%s
+after:
+  map[string]int{"a":2}
+want:
+  map[string]int{"a":3}
+`, diff(map[string]int{"a": 2}, map[string]int{"a": 3})),
+}, {
+	about:   "Changes: nil pointer",
+	checker: qt.Changes(nil, func() {}, 42),
+	expectedCheckFailure: `
+error:
+  bad check: nil pointer provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: nil pointer provided
+`,
+}, {
+	about:   "Changes: nil function",
+	checker: qt.Changes(new(int), nil, 42),
+	expectedCheckFailure: `
+error:
+  bad check: nil function provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: nil function provided
+`,
+}, {
+	about: "Unchanged: success",
+	checker: func() qt.Checker {
+		m := map[string][]int{"a": {1, 2}}
+		return qt.Unchanged(&m, func() { _ = len(m["a"]) })
+	}(),
+	expectedNegateFailure: `
+error:
+  unexpected success
+value:
+  map[string][]int{
+      "a": {1, 2},
+  }
+`,
+}, {
+	about: "Unchanged: value changed through a pointer",
+	checker: func() qt.Checker {
+		records := []*record{{ID: 1, Name: "a"}}
+		return qt.Unchanged(&records, func() { records[0].Name += "!" })
+	}(),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  value changed
+diff (-before +after):
+%s
+after:
+  []*qt_test.record{
+      &qt_test.record{
+          ID:   1,
+          Name: "a!",
+          Meta: qt_test.recordMeta{},
+      },
+  }
+before:
+  []*qt_test.record{
+      &qt_test.record{
+          ID:   1,
+          Name: "a",
+          Meta: qt_test.recordMeta{},
+      },
+  }
+`, diff([]*record{{ID: 1, Name: "a!"}}, []*record{{ID: 1, Name: "a"}})),
+}, {
+	about: "Unchanged: pointer map keys",
+	checker: func() qt.Checker {
+		m := map[*record]int{{ID: 1}: 1}
+		return qt.Unchanged(&m, func() {})
+	}(),
+	expectedNegateFailure: `
+error:
+  unexpected success
+value:
+  map[*qt_test.record]int{&qt_test.record{
+      ID:   1,
+      Name: "",
+      Meta: qt_test.recordMeta{},
+  }:1}
+`,
+}, {
+	about:   "Unchanged: nil function",
+	checker: qt.Unchanged(new(int), nil),
+	expectedCheckFailure: `
+error:
+  bad check: nil function provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: nil function provided
+`,
+}, {
+	about: "Increments: success",
+	checker: func() qt.Checker {
+		var count int
+		return qt.Increments(&count, func() { count += 2 }, 2)
+	}(),
+	expectedNegateFailure: `
+error:
+  unexpected success
+before:
+  int(2)
+after:
+  int(4)
+want delta:
+  <same as "before">
+`,
+}, {
+	about: "Increments: negative delta",
+	checker: func() qt.Checker {
+		balance := 10.5
+		return qt.Increments(&balance, func() { balance -= 0.5 }, -0.5)
+	}(),
+	expectedNegateFailure: `
+error:
+  unexpected success
+before:
+  float64(10)
+after:
+  float64(9.5)
+want delta:
+  float64(-0.5)
+`,
+}, {
+	about: "Increments: failure",
+	checker: func() qt.Checker {
+		var count uint
+		return qt.Increments(&count, func() {}, 1)
+	}(),
+	expectedCheckFailure: `
+error:
+  value did not change by the expected delta
+before:
+  uint(0)
+after:
+  <same as "before">
+delta:
+  <same as "before">
+want delta:
+  uint(1)
+`,
+}, {
+	about:   "Increments: nil function",
+	checker: qt.Increments(new(int), nil, 1),
+	expectedCheckFailure: `
+error:
+  bad check: nil function provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: nil function provided
+`,
+}, {
+	about:   "IsTrue: success",
+	checker: qt.IsTrue(true),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  bool(true)
+want:
+  <same as "got">
+`,
+}, {
+	about:   "IsTrue: failure",
+	checker: qt.IsTrue(false),
+	expectedCheckFailure: `
+error:
+  values are not equal
+got:
+  bool(false)
+want:
+  bool(true)
+`,
+}, {
+	about:   "IsTrue: success with subtype",
+	checker: qt.IsTrue(boolean(true)),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  qt_test.boolean(true)
+want:
+  <same as "got">
+`,
+}, {
+	about:   "IsTrue: failure with subtype",
+	checker: qt.IsTrue(boolean(false)),
+	expectedCheckFailure: `
+error:
+  values are not equal
+got:
+  qt_test.boolean(false)
+want:
+  qt_test.boolean(true)
+`,
+}, {
+	about:   "IsFalse: success",
+	checker: qt.IsFalse(false),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  bool(false)
+want:
+  <same as "got">
+`,
+}, {
+	about:   "IsFalse: failure",
+	checker: qt.IsFalse(true),
+	expectedCheckFailure: `
+error:
+  values are not equal
+got:
+  bool(true)
+want:
+  bool(false)
+`,
+}, {
+	about:   "StringContains match",
+	checker: qt.StringContains("hello, world", "world"),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  "hello, world"
+substr:
+  "world"
+`,
+}, {
+	about:   "StringContains no match",
+	checker: qt.StringContains("hello, world", "worlds"),
+	expectedCheckFailure: `
+error:
+  no substring match found
+got:
+  "hello, world"
+substr:
+  "worlds"
+`}, {
+	about:   "EqualsTrimmed: equal after trimming",
+	checker: qt.EqualsTrimmed(" hello\tworld\n\n", "hello\tworld"),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  ·hello→world↵
+  ↵
+want:
+  hello→world
+`,
+}, {
+	about:   "EqualsTrimmed: not equal",
+	checker: qt.EqualsTrimmed("hello  world\n", "hello world"),
+	expectedCheckFailure: `
+error:
+  values are not equal after trimming white space
+got:
+  hello··world↵
+want:
+  hello·world
+`,
+}, {
+	about:   "EqualsTrimmed: multi-line",
+	checker: qt.EqualsTrimmed("line 1\nline 2 \nline 3\n", "line 1\nline 2\nline 3"),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  values are not equal after trimming white space
+line diff (-want +got):
+%s
+got:
+  line·1↵
+  line·2·↵
+  line·3↵
+want:
+  line·1↵
+  line·2↵
+  line·3
+`, diff([]string{"line 1\n", "line 2 \n", "line 3"}, []string{"line 1\n", "line 2\n", "line 3"})),
+}, {
+	about:   "LinesEqual: equal with different line endings",
+	checker: qt.LinesEqual(strings.NewReader("a\r\nb\r\n"), strings.NewReader("a\nb")),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  a
+  b
+want:
+  a
+  b
+`,
+}, {
+	about:   "LinesEqual: different line",
+	checker: qt.LinesEqual(strings.NewReader("a\nb\nc\n"), strings.NewReader("a\r\nB\r\nc\r\n")),
+	expectedCheckFailure: `
+error:
+  lines are not equal
+line number:
+  int(2)
+got line:
+  "b"
+want line:
+  "B"
+got:
+  a
+  b
+  c
+want:
+  a
+  B
+  c
+`,
+}, {
+	about:   "LinesEqual: missing lines",
+	checker: qt.LinesEqual(strings.NewReader("a\n"), strings.NewReader("a\nb\nc\n")),
+	expectedCheckFailure: `
+error:
+  got 1 lines, want 3
+line number:
+  int(2)
+want line:
+  "b"
+got:
+  a
+want:
+  a
+  b
+  c
+`,
+}, {
+	about:   "LinesEqual: extra lines",
+	checker: qt.LinesEqual(strings.NewReader("a\nb"), strings.NewReader("")),
+	expectedCheckFailure: `
+error:
+  got 2 lines, want 0
+line number:
+  int(1)
+got line:
+  "a"
+got:
+  a
+  b
+want:
+  no lines
+`,
+}, {
+	about:   "LinesEqual: nil reader",
+	checker: qt.LinesEqual(nil, strings.NewReader("a")),
+	expectedCheckFailure: `
+error:
+  bad check: cannot read got: nil reader
+`,
+	expectedNegateFailure: `
+error:
+  bad check: cannot read got: nil reader
+`,
+}, {
+	about:   "IsValidUTF8: valid",
+	checker: qt.IsValidUTF8("héllo, 世界"),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  "héllo, 世界"
+`,
+}, {
+	about:   "IsValidUTF8: invalid",
+	checker: qt.IsValidUTF8("héllo\xff world"),
+	expectedCheckFailure: `
+error:
+  string is not valid UTF-8
+invalid byte at offset:
+  6 (0x6)
+context:
+  00000000  68 c3 a9 6c 6c 6f ff 20  77 6f 72 6c 64           |h..llo. world|
+got:
+  "héllo\xff world"
+`,
+}, {
+	about:   "IsValidUTF8: truncated sequence",
+	checker: qt.IsValidUTF8("abc\xe4\xb8"),
+	expectedCheckFailure: `
+error:
+  string is not valid UTF-8
+invalid byte at offset:
+  3 (0x3)
+context:
+  00000000  61 62 63 e4 b8                                    |abc..|
+got:
+  "abc\xe4\xb8"
+`,
+}, {
+	about:   "BytesEquals: same values",
+	checker: qt.BytesEquals([]byte("hello"), []byte("hello")),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  []uint8("hello")
+want:
+  <same as "got">
+`,
+}, {
+	about:   "BytesEquals: different values",
+	checker: qt.BytesEquals([]byte("hello world"), []byte("hello wOrld")),
+	expectedCheckFailure: `
+error:
+  byte slices are not equal
+first difference at offset:
+  7 (0x7)
+hex diff (-want +got):
+  - 00000000  68 65 6c 6c 6f 20 77 4f  72 6c 64                 |hello wOrld|
+  + 00000000  68 65 6c 6c 6f 20 77 6f  72 6c 64                 |hello world|
+`,
+}, {
+	about:   "BytesEquals: different lengths",
+	checker: qt.BytesEquals([]byte("hello"), []byte("hello\x00\xff")),
+	expectedCheckFailure: `
+error:
+  byte slices are not equal
+first difference at offset:
+  5 (0x5)
+len(got):
+  int(5)
+len(want):
+  int(7)
+hex diff (-want +got):
+  - 00000000  68 65 6c 6c 6f 00 ff                              |hello..|
+  + 00000000  68 65 6c 6c 6f                                    |hello|
+`,
+}, {
+	about:   "BytesEquals: nil and empty",
+	checker: qt.BytesEquals(nil, []byte{}),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  []uint8(nil)
+want:
+  []uint8("")
+`,
+}, {
+	about:   "BytesEquals: long values",
+	checker: qt.BytesEquals(bytesSeq(200, 100, 'x'), bytesSeq(200)),
+	expectedCheckFailure: `
+error:
+  byte slices are not equal
+first difference at offset:
+  100 (0x64)
+hex diff (-want +got):
+    ... 64 bytes omitted
+    00000040  40 41 42 43 44 45 46 47  48 49 4a 4b 4c 4d 4e 4f  |@ABCDEFGHIJKLMNO|
+    00000050  50 51 52 53 54 55 56 57  58 59 5a 5b 5c 5d 5e 5f  |PQRSTUVWXYZ[\]^_|
+  - 00000060  60 61 62 63 64 65 66 67  68 69 6a 6b 6c 6d 6e 6f  |` + "`" + `abcdefghijklmno|
+  + 00000060  60 61 62 63 78 65 66 67  68 69 6a 6b 6c 6d 6e 6f  |` + "`" + `abcxefghijklmno|
+    00000070  70 71 72 73 74 75 76 77  78 79 7a 7b 7c 7d 7e 7f  |pqrstuvwxyz{|}~.|
+    00000080  80 81 82 83 84 85 86 87  88 89 8a 8b 8c 8d 8e 8f  |................|
+    ... 56 bytes omitted
+`,
+}, {
+	about:   "BytesContains: match",
+	checker: qt.BytesContains([]byte("\x00hello\xff"), []byte("llo")),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  []uint8{0x0, 0x68, 0x65, 0x6c, 0x6c, 0x6f, 0xff}
+sub:
+  []uint8("llo")
+`,
+}, {
+	about:   "BytesContains: no match",
+	checker: qt.BytesContains([]byte("\x00hello\xff"), []byte("bad wolf")),
+	expectedCheckFailure: `
+error:
+  no subslice match found
+got:
+  00000000  00 68 65 6c 6c 6f ff                              |.hello.|
+sub:
+  00000000  62 61 64 20 77 6f 6c 66                           |bad wolf|
+`,
+}, {
+	about:   "BytesContains: truncated hex dump",
+	checker: qt.BytesContains(bytesSeq(100), []byte("bad wolf")),
+	expectedCheckFailure: `
+error:
+  no subslice match found
+got:
+  00000000  00 01 02 03 04 05 06 07  08 09 0a 0b 0c 0d 0e 0f  |................|
+  00000010  10 11 12 13 14 15 16 17  18 19 1a 1b 1c 1d 1e 1f  |................|
+  00000020  20 21 22 23 24 25 26 27  28 29 2a 2b 2c 2d 2e 2f  | !"#$%&'()*+,-./|
+  ... 52 bytes omitted
+sub:
+  00000000  62 61 64 20 77 6f 6c 66                           |bad wolf|
+`,
+}, {
+	about:   "Contains: string match",
+	checker: qt.Contains("hello world", "o w"),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  "hello world"
+substr:
+  "o w"
+`,
+}, {
+	about:   "Contains: unsupported container",
+	checker: qt.Contains(boolean(true), "t"),
+	expectedCheckFailure: `
+error:
+  bad check: cannot look for element in container of type qt_test.boolean
+`,
+	expectedNegateFailure: `
+error:
+  bad check: cannot look for element in container of type qt_test.boolean
+`,
+}, {
+	about:   "Contains: string mismatch",
+	checker: qt.Contains("hello world", "ow"),
+	expectedCheckFailure: `
+error:
+  no substring match found
+got:
+  "hello world"
+substr:
+  "ow"
+`,
+}, {
+	about:   "Contains: string with non-string element",
+	checker: qt.Contains("42", 4),
+	expectedCheckFailure: `
+error:
+  bad check: cannot look for element of type int in a string
+`,
+	expectedNegateFailure: `
+error:
+  bad check: cannot look for element of type int in a string
+`,
+}, {
+	about:   "Contains: slice match",
+	checker: qt.Contains([]int{1, 2, 3}, 2),
+	expectedNegateFailure: `
+error:
+  unexpected success
+container:
+  []int{1, 2, 3}
+want:
+  int(2)
+`,
+}, {
+	about:   "Contains: array mismatch",
+	checker: qt.Contains([3]string{"a", "b", "c"}, "d"),
+	expectedCheckFailure: `
+error:
+  no matching element found
+container:
+  [3]string{"a", "b", "c"}
+want:
+  "d"
+`,
+}, {
+	about:   "Contains: slice of interfaces",
+	checker: qt.Contains([]any{"a", 42}, 42),
+	expectedNegateFailure: `
+error:
+  unexpected success
+container:
+  []interface {}{
+      "a",
+      int(42),
+  }
+want:
+  int(42)
+`,
+}, {
+	about:   "Contains: map match",
+	checker: qt.Contains(map[string]int{"a": 1, "b": 2}, 2),
+	expectedNegateFailure: `
+error:
+  unexpected success
+container:
+  map[string]int{"a":1, "b":2}
+want:
+  int(2)
+`,
+}, {
+	about:   "Contains: map mismatch",
+	checker: qt.Contains(map[string]int{"a": 1}, 2),
+	expectedCheckFailure: `
+error:
+  no matching element found
+container:
+  map[string]int{"a":1}
+want:
+  int(2)
+`,
+}, {
+	about:   "Contains: mismatched element type",
+	checker: qt.Contains([]int{1, 2}, "1"),
+	expectedCheckFailure: `
+error:
+  bad check: cannot look for element of type string in container of element type int
+`,
+	expectedNegateFailure: `
+error:
+  bad check: cannot look for element of type string in container of element type int
+`,
+}, {
+	about:   "Contains: nil container",
+	checker: qt.Contains(nil, 1),
+	expectedCheckFailure: `
+error:
+  bad check: cannot look for element in nil container
+`,
+	expectedNegateFailure: `
+error:
+  bad check: cannot look for element in nil container
+`,
+}, {
+	about:   "FSEquals: empty file systems",
+	checker: qt.FSEquals(fstest.MapFS{}, fstest.MapFS{}),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  fstest.MapFS{
+  }
+want:
+  <same as "got">
+`,
+}, {
+	about: "FSEquals: missing path",
+	checker: qt.FSEquals(fstest.MapFS{
+		"a.txt": {Data: []byte("a")},
+	}, fstest.MapFS{
+		"a.txt":     {Data: []byte("a")},
+		"dir/b.txt": {Data: []byte("b")},
+	}),
+	expectedCheckFailure: `
+error:
+  missing path
+path:
+  "dir"
+`,
+}, {
+	about: "FSEquals: unexpected path",
+	checker: qt.FSEquals(fstest.MapFS{
+		"a.txt": {Data: []byte("a")},
+		"b.txt": {Data: []byte("b")},
+	}, fstest.MapFS{
+		"a.txt": {Data: []byte("a")},
+	}),
+	expectedCheckFailure: `
+error:
+  unexpected path
+path:
+  "b.txt"
+`,
+}, {
+	about: "FSEquals: directory instead of file",
+	checker: qt.FSEquals(fstest.MapFS{
+		"a/b.txt": {Data: []byte("b")},
+	}, fstest.MapFS{
+		"a": {Data: []byte("a")},
+	}),
+	expectedCheckFailure: `
+error:
+  got directory but want file
+path:
+  "a"
+`,
+}, {
+	about: "FSEquals: different text contents",
+	checker: qt.FSEquals(fstest.MapFS{
+		"dir/a.txt": {Data: []byte("hello\nworld\n")},
+	}, fstest.MapFS{
+		"dir/a.txt": {Data: []byte("hello\nthere\n")},
+	}),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  file contents differ
+path:
+  "dir/a.txt"
+line diff (-want +got):
+%s
+`, diff([]string{"hello\n", "world\n", ""}, []string{"hello\n", "there\n", ""})),
+}, {
+	about: "FSEquals: different binary contents",
+	checker: qt.FSEquals(fstest.MapFS{
+		"a.bin": {Data: []byte{0xff, 0x01}},
+	}, fstest.MapFS{
+		"a.bin": {Data: []byte{0xff, 0x02}},
+	}),
+	expectedCheckFailure: `
+error:
+  file contents differ
+path:
+  "a.bin"
+hex diff (-want +got):
+  - 00000000  ff 02                                             |..|
+  + 00000000  ff 01                                             |..|
+`,
+}, {
+	about:   "FSEquals: nil file system",
+	checker: qt.FSEquals(nil, fstest.MapFS{}),
+	expectedCheckFailure: `
+error:
+  bad check: nil file system provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: nil file system provided
+`,
+}, {
+	about:   "SliceContains match",
+	checker: qt.SliceContains([]string{"a", "b", "c"}, "a"),
+	expectedNegateFailure: `
+error:
+  unexpected success
+container:
+  []string{"a", "b", "c"}
+want:
+  "a"
+`,
+}, {
+	about:   "SliceContains mismatch",
+	checker: qt.SliceContains([]string{"a", "b", "c"}, "d"),
+	expectedCheckFailure: `
+error:
+  no matching element found
+container:
+  []string{"a", "b", "c"}
+want:
+  "d"
+`,
+}, {
+	about: "Contains with map",
+	checker: qt.MapContains(map[string]string{
+		"a": "d",
+		"b": "a",
+	}, "d"),
+	expectedNegateFailure: `
+error:
+  unexpected success
+container:
+  map[string]string{"a":"d", "b":"a"}
+want:
+  "d"
+`,
+}, {
+	about: "Contains with map and interface value",
+	checker: qt.MapContains(map[string]any{
+		"a": "d",
+		"b": "a",
+	}, "d"),
+	expectedNegateFailure: `
+error:
+  unexpected success
+container:
+  map[string]interface {}{
+      "a": "d",
+      "b": "a",
+  }
+want:
+  "d"
+`,
+}, {
+	about:   "All slice equals",
+	checker: qt.SliceAll([]string{"a", "a"}, qt.F2(qt.Equals[string], "a")),
+	expectedNegateFailure: `
+error:
+  unexpected success
+container:
+  []string{"a", "a"}
+want:
+  "a"
+`,
+}, {
+	about:   "All slice match",
+	checker: qt.SliceAll([]string{"red", "blue", "green"}, qt.F2(qt.Matches[string], ".*e.*")),
+	expectedNegateFailure: `
+error:
+  unexpected success
+container:
+  []string{"red", "blue", "green"}
+regexp:
+  ".*e.*"
+`,
+}, {
+	about: "All nested match",
+	// TODO this is a bit awkward. Is there something we could do to improve it?
+	checker: qt.SliceAll([][]string{{"hello", "goodbye"}, {"red", "blue"}, {}}, func(elem []string) qt.Checker {
+		return qt.SliceAll(elem, qt.F2(qt.Matches[string], ".*e.*"))
+	}),
+	expectedNegateFailure: `
+error:
+  unexpected success
+container:
+  [][]string{
+      {"hello", "goodbye"},
+      {"red", "blue"},
+      {},
+  }
+regexp:
+  ".*e.*"
+`,
+}, {
+	about: "All nested mismatch",
+	checker: qt.SliceAll([][]string{{"hello", "goodbye"}, {"black", "blue"}, {}}, func(elem []string) qt.Checker {
+		return qt.SliceAll(elem, qt.F2(qt.Matches[string], ".*e.*"))
+	}),
+	expectedCheckFailure: `
+error:
+  mismatch at index 1
+error:
+  mismatch at index 0
+error:
+  value does not match regexp
+first mismatched element:
+  "black"
+`,
+}, {
+	about:   "All slice mismatch",
+	checker: qt.SliceAll([]string{"red", "black"}, qt.F2(qt.Matches[string], ".*e.*")),
+	expectedCheckFailure: `
+error:
+  mismatch at index 1
+error:
+  value does not match regexp
+first mismatched element:
+  "black"
+`,
+}, {
+	about:   "All slice mismatch with DeepEqual",
+	checker: qt.SliceAll([][]string{{"a", "b"}, {"a", "c"}}, qt.F2(qt.DeepEquals[[]string], []string{"a", "b"})),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  mismatch at index 1
+error:
+  values are not deep equal
+diff (-want +got):
+%s
+got:
+  []string{"a", "c"}
+want:
+  []string{"a", "b"}
+`, diff([]string{"a", "c"}, []string{"a", "b"})),
+}, {
+	about:   "All mismatch with map",
+	checker: qt.MapAll(map[string]string{"a": "red", "b": "black"}, qt.F2(qt.Matches[string], ".*e.*")),
+	expectedCheckFailure: `
+error:
+  mismatch at key "b"
+error:
+  value does not match regexp
+first mismatched element:
+  "black"
+`}, {
+	about: "All mismatch with map and DeepEquals",
+	checker: qt.MapAll(map[string][]int{"a": {1, 2}, "b": {1, 3}}, func(elem []int) qt.Checker {
+		return qt.DeepEquals(elem, []int{1, 2})
+	}),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  mismatch at key "b"
+first mismatched element:
+  []int{1, 3}
+error:
+  values are not deep equal
+diff at key "b" (-want +got):
+%s
+got:
+  []int{1, 3}
+want:
+  []int{1, 2}
+`, diff([]int{1, 3}, []int{1, 2})),
+}, {
+	about:   "Any no match",
+	checker: qt.SliceAny([]int{}, qt.F2(qt.Equals[int], 5)),
+	expectedCheckFailure: `
+error:
+  no matching element found
+container:
+  []int{}
+want:
+  int(5)
+`,
+}, {
+	about:   "SliceCountMatching: success",
+	checker: qt.SliceCountMatching([]string{"ERROR a", "INFO b", "ERROR c"}, qt.F2(qt.Matches[string], "ERROR.*"), 2),
+	expectedNegateFailure: `
+error:
+  unexpected success
+container:
+  []string{"ERROR a", "INFO b", "ERROR c"}
+regexp:
+  "ERROR.*"
+at least:
+  int(2)
+`,
+}, {
+	about:   "SliceCountMatching: too few matches",
+	checker: qt.SliceCountMatching([]string{"ERROR a", "INFO b", "INFO c"}, qt.F2(qt.Matches[string], "ERROR.*"), 2),
+	expectedCheckFailure: `
+error:
+  too few matching elements
+matching elements:
+  int(1)
+container:
+  []string{"ERROR a", "INFO b", "INFO c"}
+regexp:
+  "ERROR.*"
+at least:
+  int(2)
+`,
+}, {
+	about:   "SliceCountMatching: zero required",
+	checker: qt.SliceCountMatching([]int(nil), qt.F2(qt.Equals[int], 1), 0),
+	expectedNegateFailure: `
+error:
+  unexpected success
+container:
+  []int(nil)
+want:
+  int(1)
+at least:
+  int(0)
+`,
+}, {
+	about: "SliceCountMatching: bad check",
+	checker: qt.SliceCountMatching([]int{1}, func(elem int) qt.Checker {
+		return qt.IsNil(elem)
+	}, 1),
+	expectedCheckFailure: `
+error:
+  bad check: at index 0: bad check: type int can never be nil
+`,
+	expectedNegateFailure: `
+error:
+  bad check: at index 0: bad check: type int can never be nil
+`,
+}, {
+	about:   "SliceCountMatching: negative count",
+	checker: qt.SliceCountMatching([]int{1}, qt.F2(qt.Equals[int], 1), -1),
+	expectedCheckFailure: `
+error:
+  bad check: negative count -1
+`,
+	expectedNegateFailure: `
+error:
+  bad check: negative count -1
+`,
+}, {
+	about: "SliceMatches: success",
+	checker: qt.SliceMatches([]string{"GET", "/users/42"}, []func(string) qt.Checker{
+		qt.F2(qt.Equals[string], "GET"),
+		qt.F2(qt.Matches[string], "/users/[0-9]+"),
+	}),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  []string{"GET", "/users/42"}
+`,
+}, {
+	about: "SliceMatches: mismatch",
+	checker: qt.SliceMatches([]string{"GET", "/users/bob"}, []func(string) qt.Checker{
+		qt.F2(qt.Equals[string], "GET"),
+		qt.F2(qt.Matches[string], "/users/[0-9]+"),
+	}),
+	expectedCheckFailure: `
+error:
+  mismatch at index 1
+error:
+  value does not match regexp
+mismatched element:
+  "/users/bob"
+regexp:
+  "/users/[0-9]+"
+`,
+}, {
+	about: "SliceMatches: mismatch with silent checker",
+	checker: qt.SliceMatches([][]int{{1}, {2}}, []func([]int) qt.Checker{
+		qt.F2(qt.DeepEquals[[]int], []int{1}),
+		qt.F2(qt.DeepEquals[[]int], []int{3}),
+	}),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  mismatch at index 1
+error:
+  values are not deep equal
+diff (-want +got):
+%s
+got:
+  []int{2}
+want:
+  []int{3}
+`, diff([]int{2}, []int{3})),
+}, {
+	about: "SliceMatches: length mismatch",
+	checker: qt.SliceMatches([]int{1, 2, 3}, []func(int) qt.Checker{
+		qt.F2(qt.Equals[int], 1),
+	}),
+	expectedCheckFailure: `
+error:
+  slice length does not match the number of checkers
+len(got):
+  int(3)
+len(checkers):
+  int(1)
+got:
+  []int{1, 2, 3}
+`,
+}, {
+	about: "SliceMatches: bad check",
+	checker: qt.SliceMatches([]int{1}, []func(int) qt.Checker{
+		func(elem int) qt.Checker {
+			return qt.IsNil(elem)
+		},
+	}),
+	expectedCheckFailure: `
+error:
+  bad check: at index 0: bad check: type int can never be nil
+`,
+	expectedNegateFailure: `
+error:
+  bad check: at index 0: bad check: type int can never be nil
+`,
+}, {
+	about:   "SliceMatches: nil checker function",
+	checker: qt.SliceMatches([]int{1}, []func(int) qt.Checker{nil}),
+	expectedCheckFailure: `
+error:
+  bad check: at index 0: nil checker function provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: at index 0: nil checker function provided
+`,
+}, {
+	about: "Repeatedly: success",
+	checker: qt.Repeatedly(3, func() qt.Checker {
+		return qt.Equals(strings.ToUpper("go"), "GO")
+	}),
+	expectedNegateFailure: `
+error:
+  unexpected success
+iterations:
+  int(3)
+`,
+}, {
+	about: "Repeatedly: failure",
+	checker: func() qt.Checker {
+		calls := 0
+		return qt.Repeatedly(3, func() qt.Checker {
+			calls++
+			return qt.Equals(calls, 1)
+		})
+	}(),
+	expectedCheckFailure: `
+error:
+  failure at iteration 2 of 3
+error:
+  values are not equal
+got:
+  int(2)
+want:
+  int(1)
+`,
+}, {
+	about: "Repeatedly: failure with silent checker",
+	checker: func() qt.Checker {
+		calls := 0
+		return qt.Repeatedly(2, func() qt.Checker {
+			calls++
+			return qt.DeepEquals([]int{calls}, []int{1})
+		})
+	}(),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  failure at iteration 2 of 2
+error:
+  values are not deep equal
+diff (-want +got):
+%s
+got:
+  []int{2}
+want:
+  []int{1}
+`, diff([]int{2}, []int{1})),
+}, {
+	about: "Repeatedly: zero iterations",
+	checker: qt.Repeatedly(0, func() qt.Checker {
+		return qt.IsTrue(false)
+	}),
+	expectedNegateFailure: `
+error:
+  unexpected success
+iterations:
+  int(0)
+`,
+}, {
+	about: "Repeatedly: bad check",
+	checker: qt.Repeatedly(2, func() qt.Checker {
+		return qt.IsNil(42)
+	}),
+	expectedCheckFailure: `
+error:
+  bad check: at iteration 1: bad check: type int can never be nil
+`,
+	expectedNegateFailure: `
+error:
+  bad check: at iteration 1: bad check: type int can never be nil
+`,
+}, {
+	about:   "Repeatedly: negative iterations",
+	checker: qt.Repeatedly(-1, func() qt.Checker { return qt.IsTrue(true) }),
+	expectedCheckFailure: `
+error:
+  bad check: invalid number of iterations -1
+`,
+	expectedNegateFailure: `
+error:
+  bad check: invalid number of iterations -1
+`,
+}, {
+	about:   "Repeatedly: nil function",
+	checker: qt.Repeatedly(1, nil),
+	expectedCheckFailure: `
+error:
+  bad check: nil checker function provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: nil checker function provided
+`,
+}, {
+	about: "Deterministic: same results",
+	checker: qt.Deterministic(func() map[string]int {
+		return map[string]int{"a": 1, "b": 2}
+	}, 3),
+	expectedNegateFailure: `
+error:
+  unexpected success
+calls:
+  int(3)
+`,
+}, {
+	about: "Deterministic: diverging result",
+	checker: func() qt.Checker {
+		n := 0
+		return qt.Deterministic(func() []string {
+			n++
+			if n%3 == 0 {
+				return []string{"b", "a"}
+			}
+			return []string{"a", "b"}
+		}, 3)
+	}(),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  call 3 returned a different result from call 1
+diff (-call 1 +call 3):
+%s
+call 3:
+  []string{"b", "a"}
+call 1:
+  []string{"a", "b"}
+`, diff([]string{"b", "a"}, []string{"a", "b"})),
+}, {
+	about:   "Deterministic: invalid number of calls",
+	checker: qt.Deterministic(func() int { return 0 }, 0),
+	expectedCheckFailure: `
+error:
+  bad check: invalid number of calls 0
+`,
+	expectedNegateFailure: `
+error:
+  bad check: invalid number of calls 0
+`,
+}, {
+	about: "JSONEquals simple",
+	checker: qt.JSONEquals(
+		[]byte(`{"First": 47.11}`),
+		&OuterJSON{
+			First: 47.11,
+		},
+	),
+	expectedNegateFailure: tilde2bq(`
+error:
+  unexpected success
+got:
+  []uint8(~{"First": 47.11}~)
+want:
+  &qt_test.OuterJSON{
+      First:  47.11,
+      Second: nil,
+  }
+`),
+}, {
+	about: "JSONEquals nested",
+	checker: qt.JSONEquals(
+		`{"First": 47.11, "Last": [{"First": "Hello", "Second": 42}]}`,
+		&OuterJSON{
+			First: 47.11,
+			Second: []*InnerJSON{
+				{First: "Hello", Second: 42},
+			},
+		},
+	),
+	expectedNegateFailure: tilde2bq(`
+error:
+  unexpected success
+got:
+  ~{"First": 47.11, "Last": [{"First": "Hello", "Second": 42}]}~
+want:
+  &qt_test.OuterJSON{
+      First:  47.11,
+      Second: {
+          &qt_test.InnerJSON{
+              First:  "Hello",
+              Second: 42,
+              Third:  {},
+          },
+      },
+  }
+`),
+}, {
+	about: "JSONEquals nested with newline",
+	checker: qt.JSONEquals(
+		`{"First": 47.11, "Last": [{"First": "Hello", "Second": 42},
+			{"First": "World", "Third": {"F": false}}]}`,
+		&OuterJSON{
+			First: 47.11,
+			Second: []*InnerJSON{
+				{First: "Hello", Second: 42},
+				{First: "World", Third: map[string]bool{
+					"F": false,
+				}},
+			},
+		},
+	),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  "{\"First\": 47.11, \"Last\": [{\"First\": \"Hello\", \"Second\": 42},\n\t\t\t{\"First\": \"World\", \"Third\": {\"F\": false}}]}"
+want:
+  &qt_test.OuterJSON{
+      First:  47.11,
+      Second: {
+          &qt_test.InnerJSON{
+              First:  "Hello",
+              Second: 42,
+              Third:  {},
+          },
+          &qt_test.InnerJSON{
+              First:  "World",
+              Second: 0,
+              Third:  {"F":false},
+          },
+      },
+  }
+`,
+}, {
+	about: "JSONEquals extra field",
+	checker: qt.JSONEquals(
+		`{"NotThere": 1}`,
+		&OuterJSON{
+			First: 2,
+		},
+	),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  values are not deep equal
+diff (-want +got):
+%s
+got:
+  {
+    "NotThere": 1
+  }
+want:
+  {
+    "First": 2
+  }
+`, jsonLineDiff(`{
+  "NotThere": 1
+}`, `{
+  "First": 2
+}`)),
+}, {
+	about: "JSONEquals nested mismatch",
+	checker: qt.JSONEquals(
+		`{"First": 47.11, "Last": [{"First": "Hello", "Second": 42}]}`,
+		&OuterJSON{
+			First: 47.11,
+			Second: []*InnerJSON{
+				{First: "Hello", Second: 47},
+			},
+		},
+	),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  values are not deep equal
+diff (-want +got):
+%s
+got:
+  {
+    "First": 47.11,
+    "Last": [
+      {
+        "First": "Hello",
+        "Second": 42
+      }
+    ]
+  }
+want:
+  {
+    "First": 47.11,
+    "Last": [
+      {
+        "First": "Hello",
+        "Second": 47
+      }
+    ]
+  }
+`, jsonLineDiff(`{
+  "First": 47.11,
+  "Last": [
+    {
+      "First": "Hello",
+      "Second": 42
+    }
+  ]
+}`, `{
+  "First": 47.11,
+  "Last": [
+    {
+      "First": "Hello",
+      "Second": 47
+    }
+  ]
+}`)),
+}, {
+	about: "JSONEqualsUnordered: different order",
+	checker: qt.JSONEqualsUnordered(
+		`{"tags": ["b", "a"], "users": [{"id": 2, "roles": ["x", "w"]}, {"id": 1}]}`,
+		map[string]any{
+			"tags": []string{"a", "b"},
+			"users": []any{
+				map[string]any{"id": 1},
+				map[string]any{"id": 2, "roles": []string{"w", "x"}},
+			},
+		},
+	),
+	expectedNegateFailure: tilde2bq(`
+error:
+  unexpected success
+got:
+  ~{"tags": ["b", "a"], "users": [{"id": 2, "roles": ["x", "w"]}, {"id": 1}]}~
+want:
+  map[string]interface {}{
+      "tags":  []string{"a", "b"},
+      "users": []interface {}{
+          map[string]interface {}{
+              "id": int(1),
+          },
+          map[string]interface {}{
+              "id":    int(2),
+              "roles": []string{"w", "x"},
+          },
+      },
+  }
+`),
+}, {
+	about:   "JSONEqualsUnordered: different elements",
+	checker: qt.JSONEqualsUnordered(`[3, 1, 2]`, []int{1, 2, 4}),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  values are not deep equal
+diff (-want +got):
+%s
+got:
+  [
+    1,
+    2,
+    3
+  ]
+want:
+  [
+    1,
+    2,
+    4
+  ]
+`, jsonLineDiff(`[
+  1,
+  2,
+  3
+]`, `[
+  1,
+  2,
+  4
+]`)),
+}, {
+	about:   "JSONEquals cannot unmarshal obtained value",
+	checker: qt.JSONEquals([]byte(`{"NotThere": `), nil),
+	expectedCheckFailure: fmt.Sprintf(tilde2bq(`
+error:
+  cannot unmarshal obtained contents: %s; "{\"NotThere\": "
+got:
+  []uint8(~{"NotThere": ~)
+want:
+  nil
+`), mustJSONUnmarshalErr(`{"NotThere": `)),
+}, {
+	about:   "JSONEquals cannot marshal expected value",
+	checker: qt.JSONEquals([]byte(`null`), jsonErrorMarshaler{}),
+	expectedCheckFailure: `
+error:
+  bad check: cannot marshal expected contents: json: error calling MarshalJSON for type qt_test.jsonErrorMarshaler: qt json marshal error
+`,
+	expectedNegateFailure: `
+error:
+  bad check: cannot marshal expected contents: json: error calling MarshalJSON for type qt_test.jsonErrorMarshaler: qt json marshal error
+`,
+}, {
+	about:   "JSONEquals with []byte",
+	checker: qt.JSONEquals([]byte("null"), nil),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  []uint8("null")
+want:
+  nil
+`,
+}, {
+	about:   "JSONEquals with RawMessage",
+	checker: qt.JSONEquals([]byte("null"), json.RawMessage("null")),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  []uint8("null")
+want:
+  json.RawMessage("null")
+`,
+}, {
+	about:   "IsValidJSON: valid",
+	checker: qt.IsValidJSON(`{"a": [1, 2, {"b": null}]}`),
+	expectedNegateFailure: tilde2bq(`
+error:
+  unexpected success
+got:
+  ~{"a": [1, 2, {"b": null}]}~
+`),
+}, {
+	about:   "IsValidJSON: invalid",
+	checker: qt.IsValidJSON([]byte(`{"a": [1, 2,, 3]}`)),
+	expectedCheckFailure: fmt.Sprintf(tilde2bq(`
+error:
+  invalid JSON: %v
+offset:
+  int64(13)
+context:
+  00000000  7b 22 61 22 3a 20 5b 31  2c 20 32 2c 2c 20 33 5d  |{"a": [1, 2,, 3]|
+  00000010  7d                                                |}|
+got:
+  []uint8(~{"a": [1, 2,, 3]}~)
+`), mustJSONUnmarshalErr(`{"a": [1, 2,, 3]}`)),
+}, {
+	about:   "IsValidJSON: empty",
+	checker: qt.IsValidJSON(""),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  invalid JSON: %v
+offset:
+  int64(0)
+context:
+  <empty>
+got:
+  ""
+`, mustJSONUnmarshalErr("")),
+}, {
+	about: "CodecEquals with bad marshal",
+	checker: qt.CodecEquals(
+		"null",
+		nil,
+		func(x any) ([]byte, error) { return []byte("bad json"), nil },
+		json.Unmarshal,
+	),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  bad check: cannot unmarshal expected contents: %s
+`, mustJSONUnmarshalErr("bad json")),
+	expectedNegateFailure: fmt.Sprintf(`
+error:
+  bad check: cannot unmarshal expected contents: %s
+`, mustJSONUnmarshalErr("bad json")),
+}, {
+	about: "CodecEquals with options",
+	checker: qt.CodecEquals(
+		`["b", "z", "c", "a"]`,
+		[]string{"a", "c", "z", "b"},
+		json.Marshal,
+		json.Unmarshal,
+		cmpopts.SortSlices(func(x, y any) bool { return x.(string) < y.(string) }),
+	),
+	expectedNegateFailure: tilde2bq(`
+error:
+  unexpected success
+got:
+  ~["b", "z", "c", "a"]~
+want:
+  []string{"a", "c", "z", "b"}
+`),
+}, {
+	about: "CodecEqualsReader: different values",
+	checker: qt.CodecEqualsReader(
+		strings.NewReader(`{"First": 1}`),
+		&OuterJSON{First: 2},
+		json.Marshal,
+		jsonDecode,
+	),
+	expectedCheckFailure: fmt.Sprintf(`
+error:
+  values are not deep equal
+diff (-want +got):
+%s
+got:
+  map[string]interface {}{
+      "First": float64(1),
+  }
+want:
+  map[string]interface {}{
+      "First": float64(2),
+  }
+`, diff(map[string]any{"First": 1.0}, map[string]any{"First": 2.0})),
+}, {
+	about: "CodecEqualsReader: cannot decode obtained value",
+	checker: qt.CodecEqualsReader(
+		strings.NewReader(`{"First": `),
+		nil,
+		json.Marshal,
+		jsonDecode,
+	),
+	expectedCheckFailure: `
+error:
+  cannot decode obtained contents: unexpected EOF
+got:
+  &strings.Reader{s:"{\"First\": ", i:10, prevRune:-1}
+want:
+  nil
+`,
+}, {
+	about: "CodecEqualsReader: bad marshal",
+	checker: qt.CodecEqualsReader(
+		strings.NewReader("null"),
+		nil,
+		func(x any) ([]byte, error) { return []byte("bad json"), nil },
+		jsonDecode,
+	),
+	expectedCheckFailure: `
+error:
+  bad check: cannot unmarshal expected contents: invalid character 'b' looking for beginning of value
+`,
+	expectedNegateFailure: `
+error:
+  bad check: cannot unmarshal expected contents: invalid character 'b' looking for beginning of value
+`,
+}, {
+	about:   "CodecEqualsReader: nil reader",
+	checker: qt.CodecEqualsReader(nil, nil, json.Marshal, jsonDecode),
+	expectedCheckFailure: `
+error:
+  bad check: nil reader provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: nil reader provided
+`,
+}, {
+	about:   "JSONRoundTrips: unchanged",
+	checker: qt.JSONRoundTrips(roundTripType{Name: "bob"}),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  qt_test.roundTripType{Name:"bob", Internal:0}
+`,
+}, {
+	about:   "JSONRoundTrips: changed",
+	checker: qt.JSONRoundTrips(roundTripType{Name: "bob", Internal: 42}),
+	expectedCheckFailure: fmt.Sprintf(tilde2bq(`
+error:
+  value changed after round trip
+diff (-original +round-tripped):
+%s
+round-tripped:
+  qt_test.roundTripType{Name:"bob", Internal:0}
+original:
+  qt_test.roundTripType{Name:"bob", Internal:42}
+encoded:
+  ~{"Name":"bob"}~
+`), diff(roundTripType{Name: "bob"}, roundTripType{Name: "bob", Internal: 42})),
+}, {
+	about:   "RoundTrips: marshal error",
+	checker: qt.JSONRoundTrips(func() {}),
+	expectedCheckFailure: `
+error:
+  bad check: cannot marshal value: json: unsupported type: func()
+`,
+	expectedNegateFailure: `
+error:
+  bad check: cannot marshal value: json: unsupported type: func()
+`,
+}, {
+	about: "RoundTrips: unmarshal error",
+	checker: qt.RoundTrips(42, func(int) ([]byte, error) {
+		return []byte("bad wolf"), nil
+	}, func(data []byte, v *int) error {
+		return json.Unmarshal(data, v)
+	}),
+	expectedCheckFailure: `
+error:
+  bad check: cannot unmarshal encoded value: invalid character 'b' looking for beginning of value
+encoded:
+  "bad wolf"
+`,
+	expectedNegateFailure: `
+error:
+  bad check: cannot unmarshal encoded value: invalid character 'b' looking for beginning of value
+encoded:
+  "bad wolf"
+`,
+}, {
+	about:   "RoundTrips: nil unmarshal function",
+	checker: qt.RoundTrips(42, func(int) ([]byte, error) { return nil, nil }, nil),
+	expectedCheckFailure: `
+error:
+  bad check: nil unmarshal function provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: nil unmarshal function provided
+`,
+}, {
+	about:   "ErrorAs: exact match",
+	checker: qt.ErrorAs(targetErr, new(*errTarget)),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  e"ptr: target"
+as type:
+  *qt_test.errTarget
+`,
+}, {
+	about:   "ErrorAs: wrapped match",
+	checker: qt.ErrorAs(fmt.Errorf("wrapped: %w", targetErr), new(*errTarget)),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  e"wrapped: ptr: target"
+as type:
+  *qt_test.errTarget
+`,
+}, {
+	about:   "ErrorAs: fails if nil error",
+	checker: qt.ErrorAs(nil, new(*errTarget)),
+	expectedCheckFailure: `
+error:
+  got nil error but want non-nil
+got:
+  nil
+as type:
+  *qt_test.errTarget
+`,
+}, {
+	about:   "ErrorAs: fails if mismatch",
+	checker: qt.ErrorAs(errors.New("other error"), new(*errTarget)),
+	expectedCheckFailure: `
+error:
+  wanted type is not found in error chain
+got:
+  e"other error"
+as type:
+  *qt_test.errTarget
+`,
+}, {
+	about:   "ErrorAs: fails if mismatch with a non-pointer error implementation",
+	checker: qt.ErrorAs(errors.New("other error"), new(errTargetNonPtr)),
+	expectedCheckFailure: `
+error:
+  wanted type is not found in error chain
+got:
+  e"other error"
+as type:
+  qt_test.errTargetNonPtr
+`,
+}, {
+	about:   "ErrorAs: bad check if invalid as",
+	checker: qt.ErrorAs(targetErr, &struct{}{}),
+	expectedCheckFailure: `
+error:
+  bad check: errors: *target must be interface or implement error
+`,
+	expectedNegateFailure: `
+error:
+  bad check: errors: *target must be interface or implement error
+`,
+}, {
+	about:   "HasErrorType: wrapped match",
+	checker: qt.HasErrorType[*errTarget](fmt.Errorf("wrapped: %w", targetErr)),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  e"wrapped: ptr: target"
+as type:
+  *qt_test.errTarget
+`,
+}, {
+	about:   "HasErrorType: fails if nil error",
+	checker: qt.HasErrorType[*errTarget](nil),
+	expectedCheckFailure: `
+error:
+  got nil error but want non-nil
+got:
+  nil
+as type:
+  *qt_test.errTarget
+`,
+}, {
+	about:   "HasErrorType: fails if mismatch",
+	checker: qt.HasErrorType[*errTarget](errors.New("other error")),
+	expectedCheckFailure: `
+error:
+  wanted type is not found in error chain
+got:
+  e"other error"
+as type:
+  *qt_test.errTarget
+`,
+}, {
+	about:   "HasErrorType: bad check if invalid type",
+	checker: qt.HasErrorType[struct{}](targetErr),
+	expectedCheckFailure: `
+error:
+  bad check: errors: *target must be interface or implement error
+`,
+	expectedNegateFailure: `
+error:
+  bad check: errors: *target must be interface or implement error
+`,
+}, {
+	about:   "ErrorIs: exact match",
+	checker: qt.ErrorIs(targetErr, targetErr),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  e"ptr: target"
+want:
+  <same as "got">
+`,
+}, {
+	about:   "ErrorIs: wrapped match",
+	checker: qt.ErrorIs(fmt.Errorf("wrapped: %w", targetErr), targetErr),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  e"wrapped: ptr: target"
+want:
+  e"ptr: target"
+`,
+}, {
+	about:   "ErrorIs: fails if nil error",
+	checker: qt.ErrorIs(nil, targetErr),
+	expectedCheckFailure: `
+error:
+  got nil error but want non-nil
+got:
+  nil
+want:
+  e"ptr: target"
+`,
+}, {
+	about:   "ErrorIs: fails if mismatch",
+	checker: qt.ErrorIs(errors.New("other error"), targetErr),
+	expectedCheckFailure: `
+error:
+  wanted error is not found in error chain
+got:
+  e"other error"
+want:
+  e"ptr: target"
+`,
+}, {
+	about:   "ErrorIs: nil to nil match",
+	checker: qt.ErrorIs(nil, nil),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  nil
+want:
+  <same as "got">
+`,
+}, {
+	about:   "ErrorIs: non-nil to nil mismatch",
+	checker: qt.ErrorIs(targetErr, nil),
+	expectedCheckFailure: `
+error:
+  wanted error is not found in error chain
+got:
+  e"ptr: target"
+want:
+  nil
+`,
+}, {
+	about:   "ErrorEquals: exact match",
+	checker: qt.ErrorEquals(targetErr, targetErr),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  e"ptr: target"
+want:
+  <same as "got">
+`,
+}, {
+	about:   "ErrorEquals: nil to nil match",
+	checker: qt.ErrorEquals(nil, nil),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  nil
+want:
+  <same as "got">
+`,
+}, {
+	about:   "ErrorEquals: fails if wrapped",
+	checker: qt.ErrorEquals(fmt.Errorf("wrapped: %w", targetErr), targetErr),
+	expectedCheckFailure: `
+error:
+  got error wraps wanted error but is not equivalent to it
+got:
+  e"wrapped: ptr: target"
+want:
+  e"ptr: target"
+`,
+}, {
+	about:   "ErrorEquals: fails if nil error",
+	checker: qt.ErrorEquals(nil, targetErr),
+	expectedCheckFailure: `
+error:
+  got nil error but want non-nil
+got:
+  nil
+want:
+  e"ptr: target"
+`,
+}, {
+	about:   "ErrorEquals: fails if nil wanted error",
+	checker: qt.ErrorEquals(targetErr, nil),
+	expectedCheckFailure: `
+error:
+  got non-nil error but want nil
+got:
+  e"ptr: target"
+want:
+  nil
+`,
+}, {
+	about:   "ErrorEquals: fails if mismatch",
+	checker: qt.ErrorEquals(errors.New("other error"), targetErr),
+	expectedCheckFailure: `
+error:
+  wanted error is not found in error chain
+got:
+  e"other error"
+want:
+  e"ptr: target"
+`,
+}, {
+	about:   "IsPositive: positive",
+	checker: qt.IsPositive(42),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  int(42)
+`,
+}, {
+	about:   "IsPositive: zero",
+	checker: qt.IsPositive(0.0),
+	expectedCheckFailure: `
+error:
+  value is not positive
+got:
+  float64(0)
+`,
+}, {
+	about:   "IsPositive: NaN",
+	checker: qt.IsPositive(math.NaN()),
+	expectedCheckFailure: `
+error:
+  value is not positive
+got:
+  float64(NaN)
+`,
+}, {
+	about:   "IsNegative: negative",
+	checker: qt.IsNegative(-3 * time.Second),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  s"-3s"
+`,
+}, {
+	about:   "IsNegative: zero",
+	checker: qt.IsNegative(int8(0)),
+	expectedCheckFailure: `
+error:
+  value is not negative
+got:
+  int8(0)
+`,
+}, {
+	about:   "IsNonNegative: zero",
+	checker: qt.IsNonNegative(0),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  int(0)
+`,
+}, {
+	about:   "IsNonNegative: negative",
+	checker: qt.IsNonNegative(-1.5),
+	expectedCheckFailure: `
+error:
+  value is not non-negative
+got:
+  float64(-1.5)
+`,
+}, {
+	about:   "IsMultipleOf: multiple",
+	checker: qt.IsMultipleOf(4096, 512),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  int(4096)
+factor:
+  int(512)
+`,
+}, {
+	about:   "IsMultipleOf: not a multiple",
+	checker: qt.IsMultipleOf(uint32(4100), 512),
+	expectedCheckFailure: `
+error:
+  value is not a multiple of factor
+remainder:
+  uint32(4)
+got:
+  uint32(4100)
+factor:
+  uint32(512)
+`,
+}, {
+	about:   "IsMultipleOf: negative value",
+	checker: qt.IsMultipleOf(-9, 3),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  int(-9)
+factor:
+  int(3)
+`,
+}, {
+	about:   "IsMultipleOf: zero factor",
+	checker: qt.IsMultipleOf(42, 0),
+	expectedCheckFailure: `
+error:
+  bad check: factor is zero
+`,
+	expectedNegateFailure: `
+error:
+  bad check: factor is zero
+`,
+}, {
+	about:   "ApproximatelyPercent: within tolerance",
+	checker: qt.ApproximatelyPercent(104, 100, 5),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  float64(104)
+want:
+  float64(100)
+allowed difference:
+  5%
+`,
+}, {
+	about:   "ApproximatelyPercent: negative values",
+	checker: qt.ApproximatelyPercent(-95, -100, 5),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  float64(-95)
+want:
+  float64(-100)
+allowed difference:
+  5%
+`,
+}, {
+	about:   "ApproximatelyPercent: exceeds tolerance",
+	checker: qt.ApproximatelyPercent(90, 100, 5),
+	expectedCheckFailure: `
+error:
+  values differ by more than the allowed percentage
+difference:
+  10%
+got:
+  float64(90)
+want:
+  float64(100)
+allowed difference:
+  5%
+`,
+}, {
+	about:   "ApproximatelyPercent: zero want",
+	checker: qt.ApproximatelyPercent(0.001, 0, 5),
+	expectedCheckFailure: `
+error:
+  values differ by more than the allowed percentage
+difference:
+  +Inf%
+got:
+  float64(0.001)
+want:
+  float64(0)
+allowed difference:
+  5%
+`,
+}, {
+	about:   "ApproximatelyPercent: zero want and got",
+	checker: qt.ApproximatelyPercent(0, 0, 5),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  float64(0)
+want:
+  <same as "got">
+allowed difference:
+  5%
+`,
+}, {
+	about:   "ApproximatelyPercent: NaN",
+	checker: qt.ApproximatelyPercent(math.NaN(), 100, 5),
+	expectedCheckFailure: `
+error:
+  values differ by more than the allowed percentage
+difference:
+  NaN%
+got:
+  float64(NaN)
+want:
+  float64(100)
+allowed difference:
+  5%
+`,
+}, {
+	about:   "ApproximatelyPercent: negative percentage",
+	checker: qt.ApproximatelyPercent(1, 1, -5),
+	expectedCheckFailure: `
+error:
+  bad check: invalid percentage -5
+`,
+	expectedNegateFailure: `
+error:
+  bad check: invalid percentage -5
+`,
+}, {
+	about:   "ContextDone: done",
+	checker: qt.ContextDone(canceledContext()),
+	expectedNegateFailure: `
+error:
+  unexpected success
+context error:
+  e"context canceled"
+got:
+  s"context.Background.WithCancel"
+`,
+}, {
+	about:   "ContextDone: not done",
+	checker: qt.ContextDone(context.Background()),
+	expectedCheckFailure: `
+error:
+  context is not done yet
+got:
+  s"context.Background"
+`,
+}, {
+	about:   "ContextNotDone: not done",
+	checker: qt.ContextNotDone(context.Background()),
+	expectedNegateFailure: `
+error:
+  unexpected success
+got:
+  s"context.Background"
+`,
+}, {
+	about:   "ContextNotDone: done",
+	checker: qt.ContextNotDone(canceledContext()),
+	expectedCheckFailure: `
+error:
+  context is done
+context error:
+  e"context canceled"
+got:
+  s"context.Background.WithCancel"
+`,
+}, {
+	about:   "ContextNotDone: nil context",
+	checker: qt.ContextNotDone(nil),
+	expectedCheckFailure: `
+error:
+  bad check: nil context provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: nil context provided
+`,
+}, {
+	about:   "WaitGroupDone: done",
+	checker: qt.WaitGroupDone(&sync.WaitGroup{}, time.Second),
+	expectedNegateFailure: `
+error:
+  unexpected success
+timeout:
+  s"1s"
+`,
+}, {
+	about: "WaitGroupDone: timeout",
+	checker: func() qt.Checker {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		return qt.WaitGroupDone(&wg, 10*time.Millisecond)
+	}(),
+	expectedCheckFailure: `
+error:
+  WaitGroup did not complete within 10ms
+timeout:
+  s"10ms"
+`,
+}, {
+	about:   "WaitGroupDone: nil WaitGroup",
+	checker: qt.WaitGroupDone(nil, time.Second),
+	expectedCheckFailure: `
+error:
+  bad check: nil WaitGroup provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: nil WaitGroup provided
+`,
+}, {
+	about:   "IsContextCanceled: canceled",
+	checker: qt.IsContextCanceled(fmt.Errorf("cannot fetch: %w", context.Canceled)),
+	expectedNegateFailure: `
+error:
+  unexpected context cancellation
+got:
+  e"cannot fetch: context canceled"
+want:
+  e"context canceled"
+`,
+}, {
+	about:   "IsContextCanceled: other error",
+	checker: qt.IsContextCanceled(context.DeadlineExceeded),
+	expectedCheckFailure: `
+error:
+  expected context cancellation but got another error
+got:
+  e"context deadline exceeded"
+want:
+  e"context canceled"
+`,
+}, {
+	about:   "IsContextCanceled: nil error",
+	checker: qt.IsContextCanceled(nil),
+	expectedCheckFailure: `
+error:
+  expected context cancellation but got nil error
+got:
+  nil
+want:
+  e"context canceled"
+`,
+}, {
+	about:   "IsContextDeadlineExceeded: deadline exceeded",
+	checker: qt.IsContextDeadlineExceeded(context.DeadlineExceeded),
 	expectedNegateFailure: `
 error:
-  unexpected success
-container:
-  []string{"red", "blue", "green"}
-regexp:
-  ".*e.*"
+  unexpected context deadline exceeded
+got:
+  e"context deadline exceeded"
+want:
+  <same as "got">
 `,
 }, {
-	about: "All nested match",
-	// TODO this is a bit awkward. Is there something we could do to improve it?
-	checker: qt.SliceAll([][]string{{"hello", "goodbye"}, {"red", "blue"}, {}}, func(elem []string) qt.Checker {
-		return qt.SliceAll(elem, qt.F2(qt.Matches[string], ".*e.*"))
-	}),
+	about:   "IsContextDeadlineExceeded: other error",
+	checker: qt.IsContextDeadlineExceeded(context.Canceled),
+	expectedCheckFailure: `
+error:
+  expected context deadline exceeded but got another error
+got:
+  e"context canceled"
+want:
+  e"context deadline exceeded"
+`,
+}, {
+	about:   "SliceApproximately: within tolerance",
+	checker: qt.SliceApproximately([]float64{1, 2.05}, []float64{1.05, 2}, 0.1),
 	expectedNegateFailure: `
 error:
   unexpected success
-container:
-  [][]string{
-      {"hello", "goodbye"},
-      {"red", "blue"},
-      {},
-  }
-regexp:
-  ".*e.*"
+got:
+  []float64{1, 2.05}
+want:
+  []float64{1.05, 2}
+tolerance:
+  float64(0.1)
 `,
 }, {
-	about: "All nested mismatch",
-	checker: qt.SliceAll([][]string{{"hello", "goodbye"}, {"black", "blue"}, {}}, func(elem []string) qt.Checker {
-		return qt.SliceAll(elem, qt.F2(qt.Matches[string], ".*e.*"))
-	}),
+	about:   "SliceApproximately: element exceeds tolerance",
+	checker: qt.SliceApproximately([]float32{1, 2, 3.5}, []float32{1, 2, 3}, 0.25),
 	expectedCheckFailure: `
 error:
-  mismatch at index 1
-error:
-  mismatch at index 0
-error:
-  value does not match regexp
-first mismatched element:
-  "black"
+  element differs by more than the tolerance
+index:
+  int(2)
+got element:
+  float32(3.5)
+want element:
+  float32(3)
+difference:
+  float32(0.5)
+got:
+  []float32{1, 2, 3.5}
+want:
+  []float32{1, 2, 3}
+tolerance:
+  float32(0.25)
 `,
 }, {
-	about:   "All slice mismatch",
-	checker: qt.SliceAll([]string{"red", "black"}, qt.F2(qt.Matches[string], ".*e.*")),
+	about:   "SliceApproximately: NaN element",
+	checker: qt.SliceApproximately([]float64{math.NaN()}, []float64{0}, 1),
 	expectedCheckFailure: `
 error:
-  mismatch at index 1
-error:
-  value does not match regexp
-first mismatched element:
-  "black"
+  element differs by more than the tolerance
+index:
+  int(0)
+got element:
+  float64(NaN)
+want element:
+  float64(0)
+difference:
+  <same as "got element">
+got:
+  []float64{NaN}
+want:
+  []float64{0}
+tolerance:
+  float64(1)
 `,
 }, {
-	about:   "All slice mismatch with DeepEqual",
-	checker: qt.SliceAll([][]string{{"a", "b"}, {"a", "c"}}, qt.F2(qt.DeepEquals[[]string], []string{"a", "b"})),
-	expectedCheckFailure: fmt.Sprintf(`
-error:
-  mismatch at index 1
+	about:   "SliceApproximately: different lengths",
+	checker: qt.SliceApproximately([]float64{1}, []float64{1, 2}, 0.1),
+	expectedCheckFailure: `
 error:
-  values are not deep equal
-diff (-want +got):
-%s
+  slices have different lengths
+got length:
+  int(1)
+want length:
+  int(2)
 got:
-  []string{"a", "c"}
+  []float64{1}
 want:
-  []string{"a", "b"}
-`, diff([]string{"a", "c"}, []string{"a", "b"})),
+  []float64{1, 2}
+tolerance:
+  float64(0.1)
+`,
 }, {
-	about:   "All mismatch with map",
-	checker: qt.MapAll(map[string]string{"a": "red", "b": "black"}, qt.F2(qt.Matches[string], ".*e.*")),
+	about:   "SliceApproximately: negative tolerance",
+	checker: qt.SliceApproximately([]float64{1}, []float64{1}, -1),
 	expectedCheckFailure: `
 error:
-  mismatch at key "b"
-error:
-  value does not match regexp
-first mismatched element:
-  "black"
-`}, {
-	about:   "Any no match",
-	checker: qt.SliceAny([]int{}, qt.F2(qt.Equals[int], 5)),
-	expectedCheckFailure: `
+  bad check: invalid tolerance -1
+`,
+	expectedNegateFailure: `
 error:
-  no matching element found
-container:
-  []int{}
-want:
-  int(5)
+  bad check: invalid tolerance -1
 `,
 }, {
-	about: "JSONEquals simple",
-	checker: qt.JSONEquals(
-		[]byte(`{"First": 47.11}`),
-		&OuterJSON{
-			First: 47.11,
-		},
-	),
-	expectedNegateFailure: tilde2bq(`
+	about:   "WithinDuration: same times",
+	checker: qt.WithinDuration(goTime, goTime, 0),
+	expectedNegateFailure: `
 error:
   unexpected success
 got:
-  []uint8(~{"First": 47.11}~)
+  s"2012-03-28 00:00:00 +0000 UTC"
 want:
-  &qt_test.OuterJSON{
-      First:  47.11,
-      Second: nil,
-  }
-`),
+  <same as "got">
+tolerance:
+  s"0s"
+`,
 }, {
-	about: "JSONEquals nested",
-	checker: qt.JSONEquals(
-		`{"First": 47.11, "Last": [{"First": "Hello", "Second": 42}]}`,
-		&OuterJSON{
-			First: 47.11,
-			Second: []*InnerJSON{
-				{First: "Hello", Second: 42},
-			},
-		},
-	),
-	expectedNegateFailure: tilde2bq(`
+	about:   "WithinDuration: within tolerance",
+	checker: qt.WithinDuration(goTime, goTime.Add(-time.Second), 2*time.Second),
+	expectedNegateFailure: `
 error:
   unexpected success
 got:
-  ~{"First": 47.11, "Last": [{"First": "Hello", "Second": 42}]}~
+  s"2012-03-28 00:00:00 +0000 UTC"
 want:
-  &qt_test.OuterJSON{
-      First:  47.11,
-      Second: {
-          &qt_test.InnerJSON{
-              First:  "Hello",
-              Second: 42,
-              Third:  {},
-          },
-      },
-  }
-`),
+  s"2012-03-27 23:59:59 +0000 UTC"
+tolerance:
+  s"2s"
+`,
 }, {
-	about: "JSONEquals nested with newline",
-	checker: qt.JSONEquals(
-		`{"First": 47.11, "Last": [{"First": "Hello", "Second": 42},
-			{"First": "World", "Third": {"F": false}}]}`,
-		&OuterJSON{
-			First: 47.11,
-			Second: []*InnerJSON{
-				{First: "Hello", Second: 42},
-				{First: "World", Third: map[string]bool{
-					"F": false,
-				}},
-			},
-		},
-	),
+	about:   "WithinDuration: same instant in different locations",
+	checker: qt.WithinDuration(goTime, goTime.In(time.FixedZone("UTC+1", 3600)), 0),
 	expectedNegateFailure: `
 error:
   unexpected success
 got:
-  "{\"First\": 47.11, \"Last\": [{\"First\": \"Hello\", \"Second\": 42},\n\t\t\t{\"First\": \"World\", \"Third\": {\"F\": false}}]}"
+  s"2012-03-28 00:00:00 +0000 UTC"
 want:
-  &qt_test.OuterJSON{
-      First:  47.11,
-      Second: {
-          &qt_test.InnerJSON{
-              First:  "Hello",
-              Second: 42,
-              Third:  {},
-          },
-          &qt_test.InnerJSON{
-              First:  "World",
-              Second: 0,
-              Third:  {"F":false},
-          },
-      },
-  }
+  s"2012-03-28 01:00:00 +0100 UTC+1"
+tolerance:
+  s"0s"
 `,
 }, {
-	about: "JSONEquals extra field",
-	checker: qt.JSONEquals(
-		`{"NotThere": 1}`,
-		&OuterJSON{
-			First: 2,
-		},
-	),
-	expectedCheckFailure: fmt.Sprintf(`
+	about:   "WithinDuration: exceeds tolerance",
+	checker: qt.WithinDuration(goTime, goTime.Add(1500*time.Millisecond), time.Second),
+	expectedCheckFailure: `
 error:
-  values are not deep equal
-diff (-want +got):
-%s
+  time difference exceeds tolerance
+difference:
+  s"1.5s"
 got:
-  map[string]interface {}{
-      "NotThere": float64(1),
-  }
+  s"2012-03-28 00:00:00 +0000 UTC"
 want:
-  map[string]interface {}{
-      "First": float64(2),
-  }
-`, diff(map[string]any{"NotThere": 1.0}, map[string]any{"First": 2.0})),
+  s"2012-03-28 00:00:01.5 +0000 UTC"
+tolerance:
+  s"1s"
+`,
 }, {
-	about:   "JSONEquals cannot unmarshal obtained value",
-	checker: qt.JSONEquals([]byte(`{"NotThere": `), nil),
-	expectedCheckFailure: fmt.Sprintf(tilde2bq(`
+	about:   "WithinDuration: negative tolerance",
+	checker: qt.WithinDuration(goTime, goTime, -time.Second),
+	expectedCheckFailure: `
 error:
-  cannot unmarshal obtained contents: %s; "{\"NotThere\": "
+  bad check: negative tolerance -1s
+`,
+	expectedNegateFailure: `
+error:
+  bad check: negative tolerance -1s
+`,
+}, {
+	about:   "EqualsMethod: equal",
+	checker: qt.EqualsMethod(goTime, goTime.In(time.FixedZone("UTC+1", 3600))),
+	expectedNegateFailure: `
+error:
+  unexpected success
 got:
-  []uint8(~{"NotThere": ~)
+  s"2012-03-28 00:00:00 +0000 UTC"
 want:
-  nil
-`), mustJSONUnmarshalErr(`{"NotThere": `)),
+  s"2012-03-28 01:00:00 +0100 UTC+1"
+`,
 }, {
-	about:   "JSONEquals cannot marshal expected value",
-	checker: qt.JSONEquals([]byte(`null`), jsonErrorMarshaler{}),
+	about:   "EqualsMethod: not equal",
+	checker: qt.EqualsMethod(net.ParseIP("127.0.0.1"), net.ParseIP("::1")),
 	expectedCheckFailure: `
 error:
-  bad check: cannot marshal expected contents: json: error calling MarshalJSON for type qt_test.jsonErrorMarshaler: qt json marshal error
-`,
-	expectedNegateFailure: `
-error:
-  bad check: cannot marshal expected contents: json: error calling MarshalJSON for type qt_test.jsonErrorMarshaler: qt json marshal error
+  values are not equal according to their Equal method
+got:
+  s"127.0.0.1"
+want:
+  s"::1"
 `,
 }, {
-	about:   "JSONEquals with []byte",
-	checker: qt.JSONEquals([]byte("null"), nil),
+	about:   "TimeEquals: same times",
+	checker: qt.TimeEquals(goTime, goTime),
 	expectedNegateFailure: `
 error:
   unexpected success
 got:
-  []uint8("null")
+  s"2012-03-28 00:00:00 +0000 UTC"
 want:
-  nil
+  <same as "got">
 `,
 }, {
-	about:   "JSONEquals with RawMessage",
-	checker: qt.JSONEquals([]byte("null"), json.RawMessage("null")),
+	about:   "TimeEquals: same instant in different locations",
+	checker: qt.TimeEquals(goTime, goTime.In(time.FixedZone("UTC+1", 3600))),
 	expectedNegateFailure: `
 error:
   unexpected success
 got:
-  []uint8("null")
+  s"2012-03-28 00:00:00 +0000 UTC"
 want:
-  json.RawMessage("null")
+  s"2012-03-28 01:00:00 +0100 UTC+1"
 `,
 }, {
-	about: "CodecEquals with bad marshal",
-	checker: qt.CodecEquals(
-		"null",
-		nil,
-		func(x any) ([]byte, error) { return []byte("bad json"), nil },
-		json.Unmarshal,
-	),
-	expectedCheckFailure: fmt.Sprintf(`
+	about:   "TimeEquals: different times",
+	checker: qt.TimeEquals(goTime.Add(time.Millisecond), goTime),
+	expectedCheckFailure: `
 error:
-  bad check: cannot unmarshal expected contents: %s
-`, mustJSONUnmarshalErr("bad json")),
-	expectedNegateFailure: fmt.Sprintf(`
+  times are not equal
+difference:
+  s"1ms"
+got UnixNano:
+  int64(1332892800001000000)
+want UnixNano:
+  int64(1332892800000000000)
+got:
+  s"2012-03-28 00:00:00.001 +0000 UTC"
+want:
+  s"2012-03-28 00:00:00 +0000 UTC"
+`,
+}, {
+	about:   "IsWeekday: weekday",
+	checker: qt.IsWeekday(goTime),
+	expectedNegateFailure: `
 error:
-  bad check: cannot unmarshal expected contents: %s
-`, mustJSONUnmarshalErr("bad json")),
+  unexpected success
+got:
+  s"2012-03-28 00:00:00 +0000 UTC"
+`,
 }, {
-	about: "CodecEquals with options",
-	checker: qt.CodecEquals(
-		`["b", "z", "c", "a"]`,
-		[]string{"a", "c", "z", "b"},
-		json.Marshal,
-		json.Unmarshal,
-		cmpopts.SortSlices(func(x, y any) bool { return x.(string) < y.(string) }),
-	),
-	expectedNegateFailure: tilde2bq(`
+	about:   "IsWeekday: weekend",
+	checker: qt.IsWeekday(goTime.AddDate(0, 0, 3)),
+	expectedCheckFailure: `
+error:
+  time falls on Saturday
+got:
+  s"2012-03-31 00:00:00 +0000 UTC"
+`,
+}, {
+	about:   "HourBetween: within range",
+	checker: qt.HourBetween(goTime.Add(17*time.Hour+30*time.Minute), 9, 17),
+	expectedNegateFailure: `
 error:
   unexpected success
 got:
-  ~["b", "z", "c", "a"]~
-want:
-  []string{"a", "c", "z", "b"}
-`),
+  s"2012-03-28 17:30:00 +0000 UTC"
+`,
 }, {
-	about:   "ErrorAs: exact match",
-	checker: qt.ErrorAs(targetErr, new(*errTarget)),
+	about:   "HourBetween: outside range",
+	checker: qt.HourBetween(goTime.Add(23*time.Hour), 9, 17),
+	expectedCheckFailure: `
+error:
+  hour 23 is outside [9,17]
+got:
+  s"2012-03-28 23:00:00 +0000 UTC"
+`,
+}, {
+	about:   "HourBetween: in the location of the time",
+	checker: qt.HourBetween(goTime.In(time.FixedZone("UTC+10", 10*60*60)), 9, 17),
 	expectedNegateFailure: `
 error:
   unexpected success
 got:
-  e"ptr: target"
-as type:
-  *qt_test.errTarget
+  s"2012-03-28 10:00:00 +1000 UTC+10"
 `,
 }, {
-	about:   "ErrorAs: wrapped match",
-	checker: qt.ErrorAs(fmt.Errorf("wrapped: %w", targetErr), new(*errTarget)),
+	about:   "HourBetween: invalid range",
+	checker: qt.HourBetween(goTime, 17, 9),
+	expectedCheckFailure: `
+error:
+  bad check: invalid hour range [17,9]
+`,
+	expectedNegateFailure: `
+error:
+  bad check: invalid hour range [17,9]
+`,
+}, {
+	about:   "DurationEquals: same durations",
+	checker: qt.DurationEquals(1500*time.Millisecond, 3*time.Second/2),
 	expectedNegateFailure: `
 error:
   unexpected success
 got:
-  e"wrapped: ptr: target"
-as type:
-  *qt_test.errTarget
+  s"1.5s"
+want:
+  <same as "got">
 `,
 }, {
-	about:   "ErrorAs: fails if nil error",
-	checker: qt.ErrorAs(nil, new(*errTarget)),
+	about:   "DurationEquals: different durations",
+	checker: qt.DurationEquals(1200*time.Millisecond, 1500*time.Millisecond),
 	expectedCheckFailure: `
 error:
-  got nil error but want non-nil
+  durations are not equal
+difference:
+  s"-300ms"
 got:
-  nil
-as type:
-  *qt_test.errTarget
+  s"1.2s"
+want:
+  s"1.5s"
 `,
 }, {
-	about:   "ErrorAs: fails if mismatch",
-	checker: qt.ErrorAs(errors.New("other error"), new(*errTarget)),
-	expectedCheckFailure: `
+	about:   "DurationWithin: within tolerance",
+	checker: qt.DurationWithin(1200*time.Millisecond, time.Second, 200*time.Millisecond),
+	expectedNegateFailure: `
 error:
-  wanted type is not found in error chain
+  unexpected success
 got:
-  e"other error"
-as type:
-  *qt_test.errTarget
+  s"1.2s"
+want:
+  s"1s"
+tolerance:
+  s"200ms"
 `,
 }, {
-	about:   "ErrorAs: fails if mismatch with a non-pointer error implementation",
-	checker: qt.ErrorAs(errors.New("other error"), new(errTargetNonPtr)),
+	about:   "DurationWithin: exceeds tolerance",
+	checker: qt.DurationWithin(time.Second, 1500*time.Millisecond, 100*time.Millisecond),
 	expectedCheckFailure: `
 error:
-  wanted type is not found in error chain
+  duration difference exceeds tolerance
+difference:
+  s"-500ms"
 got:
-  e"other error"
-as type:
-  qt_test.errTargetNonPtr
+  s"1s"
+want:
+  s"1.5s"
+tolerance:
+  s"100ms"
 `,
 }, {
-	about:   "ErrorAs: bad check if invalid as",
-	checker: qt.ErrorAs(targetErr, &struct{}{}),
+	about:   "DurationWithin: negative tolerance",
+	checker: qt.DurationWithin(time.Second, time.Second, -time.Second),
 	expectedCheckFailure: `
 error:
-  bad check: errors: *target must be interface or implement error
+  bad check: negative tolerance -1s
 `,
 	expectedNegateFailure: `
 error:
-  bad check: errors: *target must be interface or implement error
+  bad check: negative tolerance -1s
 `,
 }, {
-	about:   "ErrorIs: exact match",
-	checker: qt.ErrorIs(targetErr, targetErr),
+	about:   "All: success",
+	checker: qt.All(qt.Equals(42, 42), qt.IsTrue(true)),
 	expectedNegateFailure: `
 error:
   unexpected success
-got:
-  e"ptr: target"
-want:
-  <same as "got">
+check 1 got:
+  int(42)
+check 1 want:
+  <same as "check 1 got">
+check 2 got:
+  bool(true)
+check 2 want:
+  <same as "check 2 got">
 `,
 }, {
-	about:   "ErrorIs: wrapped match",
-	checker: qt.ErrorIs(fmt.Errorf("wrapped: %w", targetErr), targetErr),
+	about:   "All: no checkers",
+	checker: qt.All(),
 	expectedNegateFailure: `
 error:
   unexpected success
+`,
+}, {
+	about:   "All: failure",
+	checker: qt.All(qt.Equals(42, 42), qt.Equals("a", "b"), qt.IsTrue(true)),
+	expectedCheckFailure: `
+error:
+  check 2 of 3 failed
+error:
+  values are not equal
 got:
-  e"wrapped: ptr: target"
+  "a"
 want:
-  e"ptr: target"
+  "b"
 `,
 }, {
-	about:   "ErrorIs: fails if nil error",
-	checker: qt.ErrorIs(nil, targetErr),
-	expectedCheckFailure: `
+	about:   "All: silent failure",
+	checker: qt.All(qt.DeepEquals([]int{1}, []int{2})),
+	expectedCheckFailure: fmt.Sprintf(`
 error:
-  got nil error but want non-nil
+  check 1 of 1 failed
+error:
+  values are not deep equal
+diff (-want +got):
+%s
 got:
-  nil
+  []int{1}
 want:
-  e"ptr: target"
+  []int{2}
+`, diff([]int{1}, []int{2})),
+}, {
+	about:   "All: bad check",
+	checker: qt.All(qt.Equals(1, 1), qt.IsNil(42), qt.Equals(1, 2)),
+	expectedCheckFailure: `
+error:
+  bad check: check 2 of 3: bad check: type int can never be nil
+`,
+	expectedNegateFailure: `
+error:
+  bad check: check 2 of 3: bad check: type int can never be nil
 `,
 }, {
-	about:   "ErrorIs: fails if mismatch",
-	checker: qt.ErrorIs(errors.New("other error"), targetErr),
+	about:   "All: failure before bad check",
+	checker: qt.All(qt.Equals(1, 2), qt.IsNil(42)),
 	expectedCheckFailure: `
 error:
-  wanted error is not found in error chain
+  check 1 of 2 failed
+error:
+  values are not equal
 got:
-  e"other error"
+  int(1)
 want:
-  e"ptr: target"
+  int(2)
 `,
 }, {
-	about:   "ErrorIs: nil to nil match",
-	checker: qt.ErrorIs(nil, nil),
+	about:   "All: nil checker",
+	checker: qt.All(qt.Equals(1, 1), nil),
+	expectedCheckFailure: `
+error:
+  bad check: check 2 of 2: nil checker provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: check 2 of 2: nil checker provided
+`,
+}, {
+	about: "NewChecker: success",
+	checker: qt.NewChecker([]qt.Arg{{Name: "got", Value: 42}}, func(note func(key string, value any)) error {
+		return nil
+	}),
 	expectedNegateFailure: `
 error:
   unexpected success
 got:
-  nil
-want:
-  <same as "got">
+  int(42)
 `,
 }, {
-	about:   "ErrorIs: non-nil to nil mismatch",
-	checker: qt.ErrorIs(targetErr, nil),
+	about: "NewChecker: failure",
+	checker: qt.NewChecker([]qt.Arg{{Name: "got", Value: 42}, {Name: "want", Value: "even"}}, func(note func(key string, value any)) error {
+		note("remainder", 1)
+		return errors.New("value is not even")
+	}),
 	expectedCheckFailure: `
 error:
-  wanted error is not found in error chain
+  value is not even
+remainder:
+  int(1)
 got:
-  e"ptr: target"
+  int(42)
 want:
-  nil
+  "even"
+`,
+}, {
+	about:   "NewChecker: nil check function",
+	checker: qt.NewChecker(nil, nil),
+	expectedCheckFailure: `
+error:
+  bad check: nil check function provided
+`,
+	expectedNegateFailure: `
+error:
+  bad check: nil check function provided
 `,
 }, {
 	about:   "Not: failure",
@@ -2016,6 +6640,460 @@ func TestCheckers(t *testing.T) {
 	}
 }
 
+var readerEqualsTests = []struct {
+	about           string
+	got             func() io.Reader
+	want            func() io.Reader
+	expectedFailure string
+}{{
+	about: "equal contents",
+	got: func() io.Reader {
+		return strings.NewReader(strings.Repeat("bad wolf\n", 1000))
+	},
+	want: func() io.Reader {
+		return bytes.NewReader([]byte(strings.Repeat("bad wolf\n", 1000)))
+	},
+}, {
+	about: "empty readers",
+	got: func() io.Reader {
+		return strings.NewReader("")
+	},
+	want: func() io.Reader {
+		return strings.NewReader("")
+	},
+}, {
+	about: "different text",
+	got: func() io.Reader {
+		return strings.NewReader("hello\nthere\n")
+	},
+	want: func() io.Reader {
+		return strings.NewReader("hello\nworld\n")
+	},
+	expectedFailure: fmt.Sprintf(`
+error:
+  reader contents are not equal
+first difference at offset:
+  6 (0x6)
+line diff (-want +got):
+%s
+`, diff([]string{"hello\n", "there\n", ""}, []string{"hello\n", "world\n", ""})),
+}, {
+	about: "got is shorter",
+	got: func() io.Reader {
+		return strings.NewReader("abc")
+	},
+	want: func() io.Reader {
+		return strings.NewReader("abcd")
+	},
+	expectedFailure: fmt.Sprintf(`
+error:
+  reader contents are not equal
+first difference at offset:
+  3 (0x3)
+line diff (-want +got):
+%s
+`, diff([]string{"abc"}, []string{"abcd"})),
+}, {
+	about: "different binary data after the first chunk",
+	got: func() io.Reader {
+		b := bytes.Repeat([]byte{0xff}, 5000)
+		b[4100] = 0
+		return bytes.NewReader(b)
+	},
+	want: func() io.Reader {
+		return bytes.NewReader(bytes.Repeat([]byte{0xff}, 5000))
+	},
+	expectedFailure: `
+error:
+  reader contents are not equal
+first difference at offset:
+  4100 (0x1004)
+hex diff (-want +got):
+    ... 4064 bytes omitted
+    00000fe0  ff ff ff ff ff ff ff ff  ff ff ff ff ff ff ff ff  |................|
+    00000ff0  ff ff ff ff ff ff ff ff  ff ff ff ff ff ff ff ff  |................|
+  - 00001000  ff ff ff ff ff ff ff ff  ff ff ff ff ff ff ff ff  |................|
+  + 00001000  ff ff ff ff 00 ff ff ff  ff ff ff ff ff ff ff ff  |................|
+    00001010  ff ff ff ff ff ff ff ff  ff ff ff ff ff ff ff ff  |................|
+    00001020  ff ff ff ff ff ff ff ff  ff ff ff ff ff ff ff ff  |................|
+`,
+}, {
+	about: "nil reader",
+	got: func() io.Reader {
+		return nil
+	},
+	want: func() io.Reader {
+		return strings.NewReader("")
+	},
+	expectedFailure: `
+error:
+  bad check: nil reader provided
+`,
+}, {
+	about: "read error",
+	got: func() io.Reader {
+		return iotest.ErrReader(errors.New("bad wolf"))
+	},
+	want: func() io.Reader {
+		return strings.NewReader("")
+	},
+	expectedFailure: `
+error:
+  cannot read obtained contents: bad wolf
+got:
+  &iotest.errReader{
+      err: &errors.errorString{s:"bad wolf"},
+  }
+want:
+  &strings.Reader{s:"", i:0, prevRune:-1}
+`,
+}}
+
+func TestReaderEquals(t *testing.T) {
+	for _, test := range readerEqualsTests {
+		t.Run(test.about, func(t *testing.T) {
+			tt := &testingT{}
+			ok := qt.Check(tt, qt.ReaderEquals(test.got(), test.want()))
+			checkResult(t, ok, tt.errorString(), test.expectedFailure)
+		})
+	}
+}
+
+func TestRegisterCmpOption(t *testing.T) {
+	qt.Patch(t, qt.CmpOptions, nil)
+	type amount struct {
+		Value    int
+		Currency string
+	}
+	qt.RegisterCmpOption(cmp.Comparer(func(a, b amount) bool {
+		return a.Value == b.Value && strings.EqualFold(a.Currency, b.Currency)
+	}))
+	got, want := amount{42, "eur"}, amount{42, "EUR"}
+
+	tt := &testingT{}
+	ok := qt.Check(tt, qt.DeepEquals(got, want))
+	checkResult(t, ok, tt.errorString(), "")
+
+	tt = &testingT{}
+	ok = qt.Check(tt, qt.ContentEquals([]amount{got, {1, "usd"}}, []amount{{1, "USD"}, want}))
+	checkResult(t, ok, tt.errorString(), "")
+
+	// CmpEquals does not use registered options.
+	tt = &testingT{}
+	ok = qt.Check(tt, qt.CmpEquals(got, want))
+	assertBool(t, ok, false)
+}
+
+func TestInThePastInTheFuture(t *testing.T) {
+	qt.Patch(t, qt.TimeNow, func() time.Time { return goTime })
+
+	tt := &testingT{}
+	ok := qt.Check(tt, qt.InThePast(goTime.Add(-time.Minute)))
+	checkResult(t, ok, tt.errorString(), "")
+
+	tt = &testingT{}
+	ok = qt.Check(tt, qt.InTheFuture(goTime.Add(-3*time.Second)))
+	checkResult(t, ok, tt.errorString(), `
+error:
+  time is not in the future (it was 3s ago)
+now:
+  s"2012-03-28 00:00:00 +0000 UTC"
+got:
+  s"2012-03-27 23:59:57 +0000 UTC"
+`)
+
+	tt = &testingT{}
+	ok = qt.Check(tt, qt.InTheFuture(goTime.Add(time.Hour)))
+	checkResult(t, ok, tt.errorString(), "")
+
+	tt = &testingT{}
+	ok = qt.Check(tt, qt.InThePast(goTime.Add(90*time.Second)))
+	checkResult(t, ok, tt.errorString(), `
+error:
+  time is not in the past (it is in 1m30s)
+now:
+  s"2012-03-28 00:00:00 +0000 UTC"
+got:
+  s"2012-03-28 00:01:30 +0000 UTC"
+`)
+
+	tt = &testingT{}
+	ok = qt.Check(tt, qt.InThePast(goTime))
+	checkResult(t, ok, tt.errorString(), `
+error:
+  time is not in the past (it is now)
+now:
+  s"2012-03-28 00:00:00 +0000 UTC"
+got:
+  <same as "now">
+`)
+
+	// The current time is retrieved each time the check is run.
+	checker := qt.InTheFuture(goTime.Add(time.Minute))
+	qt.Patch(t, qt.TimeNow, func() time.Time { return goTime.Add(time.Hour) })
+	tt = &testingT{}
+	ok = qt.Check(tt, checker)
+	assertBool(t, ok, false)
+	qt.Patch(t, qt.TimeNow, func() time.Time { return goTime })
+	tt = &testingT{}
+	ok = qt.Check(tt, qt.Not(checker))
+	checkResult(t, ok, tt.errorString(), `
+error:
+  unexpected success
+now:
+  s"2012-03-28 00:00:00 +0000 UTC"
+got:
+  s"2012-03-28 00:01:00 +0000 UTC"
+`)
+}
+
+// compareTimes is a three-way comparison function for times.
+func compareTimes(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+// canceledContext returns a context that is already canceled.
+func canceledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+func TestAnchorPatterns(t *testing.T) {
+	qt.Patch(t, &qt.AnchorPatterns, false)
+
+	tt := &testingT{}
+	ok := qt.Check(tt, qt.Matches("exterminate", "term"))
+	checkResult(t, ok, tt.errorString(), "")
+
+	tt = &testingT{}
+	ok = qt.Check(tt, qt.ErrorMatches(errBadWolf, "wolf"))
+	checkResult(t, ok, tt.errorString(), "")
+
+	// Anchors can still be provided explicitly.
+	tt = &testingT{}
+	ok = qt.Check(tt, qt.Matches("exterminate", "^term$"))
+	checkResult(t, ok, tt.errorString(), `
+error:
+  value does not match regexp
+got value:
+  "exterminate"
+regexp:
+  "^term$"
+`)
+}
+
+func TestAlwaysWithin(t *testing.T) {
+	// Use a fake clock advancing by one millisecond each time it is read.
+	now := goTime
+	qt.Patch(t, qt.TimeNow, func() time.Time {
+		now = now.Add(time.Millisecond)
+		return now
+	})
+	counter := func(values ...int) func() int {
+		i := -1
+		return func() int {
+			i++
+			return values[i%len(values)]
+		}
+	}
+
+	tt := &testingT{}
+	ok := qt.Check(tt, qt.AlwaysWithin(counter(1, 2, 3), 1, 3, 10*time.Millisecond))
+	checkResult(t, ok, tt.errorString(), "")
+
+	tt = &testingT{}
+	ok = qt.Check(tt, qt.AlwaysWithin(counter(1, 2, 3, 5), 1, 3, time.Second))
+	checkResult(t, ok, tt.errorString(), `
+error:
+  sampled value out of bounds
+sample:
+  int(5)
+sample number:
+  int(4)
+elapsed:
+  s"4ms"
+min:
+  int(1)
+max:
+  int(3)
+duration:
+  s"1s"
+`)
+
+	tt = &testingT{}
+	ok = qt.Check(tt, qt.AlwaysWithin(counter(1), 3, 1, time.Second))
+	checkResult(t, ok, tt.errorString(), `
+error:
+  bad check: invalid bounds: max is less than min
+`)
+
+	tt = &testingT{}
+	ok = qt.Check(tt, qt.AlwaysWithin[string](nil, "a", "z", time.Second))
+	checkResult(t, ok, tt.errorString(), `
+error:
+  bad check: nil sample function provided
+`)
+}
+
+func TestBodyMatchesPreservesBody(t *testing.T) {
+	resp := newResponse(http.StatusOK, "hello world")
+	tt := &testingT{}
+	ok := qt.Check(tt, qt.BodyMatches(resp, "hello.*"))
+	checkResult(t, ok, tt.errorString(), "")
+	ok = qt.Check(tt, qt.BodyMatches(resp, ".*world"))
+	checkResult(t, ok, tt.errorString(), "")
+	data, err := io.ReadAll(resp.Body)
+	assertBool(t, err == nil, true)
+	assertBool(t, string(data) == "hello world", true)
+}
+
+// newResponse returns an HTTP response with the given status, body and
+// header key/value pairs.
+func newResponse(status int, body string, header ...string) *http.Response {
+	resp := &http.Response{
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+	for i := 0; i < len(header); i += 2 {
+		resp.Header.Add(header[i], header[i+1])
+	}
+	return resp
+}
+
+func TestRegisteredCmpOptionsUsed(t *testing.T) {
+	type name string
+	type person struct {
+		Name name
+		Age  int
+	}
+	qt.Patch(t, qt.CmpOptions, nil)
+	qt.RegisterCmpOption(cmp.Comparer(func(a, b name) bool {
+		return strings.EqualFold(string(a), string(b))
+	}))
+	checkers := map[string]qt.Checker{
+		"DeepEquals":              qt.DeepEquals(name("Bob"), "bob"),
+		"DeepEqualsEquatingEmpty": qt.DeepEqualsEquatingEmpty([]name{"Bob"}, []name{"bob"}),
+		"DeepEqualsApprox":        qt.DeepEqualsApprox([]name{"Bob"}, []name{"bob"}, 0, 0.1),
+		"DeepEqualsIgnoring":      qt.DeepEqualsIgnoring(person{Name: "Bob", Age: 42}, person{Name: "bob"}, "Age"),
+		"DeepOneOf":               qt.DeepOneOf([]name{"Bob"}, []name{"alice"}, []name{"bob"}),
+		"MapSubset":               qt.MapSubset(map[int]name{1: "Bob"}, map[int]name{1: "bob", 2: "alice"}),
+		"SliceStartsWith":         qt.SliceStartsWith([]name{"Bob", "alice"}, []name{"bob"}),
+		"SliceEndsWith":           qt.SliceEndsWith([]name{"Bob", "alice"}, []name{"ALICE"}),
+	}
+	for checkerName, checker := range checkers {
+		tt := &testingT{}
+		if !qt.Check(tt, checker) {
+			t.Errorf("%s: registered option not used:\n%s", checkerName, tt.errorString())
+		}
+	}
+}
+
+func TestCmpEqualsDoesNotModifyOptions(t *testing.T) {
+	// The options slice has spare capacity, as when it is shared by
+	// parallel tests appending their own options.
+	opts := make([]cmp.Option, 1, 2)
+	opts[0] = qt.ReportFirstDifference
+	sentinel := cmp.Comparer(func(a, b int) bool { return a == b })
+	opts[:2][1] = sentinel
+	qt.Check(&testingT{}, qt.CmpEquals([]int{1}, []int{2}, opts...))
+	if opts[:2][1] != sentinel {
+		t.Fatalf("the options backing array was modified")
+	}
+}
+
+func TestReportFirstDifferenceRegistered(t *testing.T) {
+	qt.Patch(t, qt.CmpOptions, nil)
+	qt.RegisterCmpOption(qt.ReportFirstDifference)
+	got := &OuterJSON{
+		First: 47.11,
+		Second: []*InnerJSON{
+			{First: "hello", Second: 42, Third: map[string]bool{"ok": false}},
+		},
+	}
+	want := &OuterJSON{
+		First: 47.11,
+		Second: []*InnerJSON{
+			{First: "hello", Second: 42, Third: map[string]bool{"ok": true}},
+		},
+	}
+	tt := &testingT{}
+	ok := qt.Check(tt, qt.DeepEquals(got, want))
+	assertBool(t, ok, false)
+	assertBool(t, strings.Contains(tt.errorString(), "first difference at:\n  root.Second[0].Third[\"ok\"]\n"), true)
+}
+
+func TestNoZeroFieldsDeepCyclicValue(t *testing.T) {
+	type node struct {
+		Value int
+		Next  *node
+	}
+	n := &node{Value: 1}
+	n.Next = &node{Next: n}
+	tt := &testingT{}
+	ok := qt.Check(tt, qt.NoZeroFieldsDeep(n))
+	assertBool(t, ok, false)
+	assertPrefix(t, tt.errorString(), "\nerror:\n  struct has zero-valued fields\nzero fields:\n  Next.Value\n")
+}
+
+func TestNoZeroFieldsDeepPointers(t *testing.T) {
+	type meta struct {
+		Owner string
+		Tag   string
+	}
+	type value struct {
+		First  *meta
+		Second *meta
+	}
+	// A nil pointer is reported even when some of its fields are ignored.
+	tt := &testingT{}
+	ok := qt.Check(tt, qt.NoZeroFieldsDeep(value{Second: &meta{Owner: "a", Tag: "b"}}, "First.Owner"))
+	assertBool(t, ok, false)
+	assertPrefix(t, tt.errorString(), "\nerror:\n  struct has zero-valued fields\nzero fields:\n  First\ngot:\n")
+	// A pointer reached twice without a cycle is checked both times.
+	m := &meta{Owner: "a"}
+	tt = &testingT{}
+	ok = qt.Check(tt, qt.NoZeroFieldsDeep(value{First: m, Second: m}))
+	assertBool(t, ok, false)
+	assertPrefix(t, tt.errorString(), "\nerror:\n  struct has zero-valued fields\nzero fields:\n  First.Tag\n  Second.Tag\ngot:\n")
+	// Ignored paths are validated against the type.
+	tt = &testingT{}
+	ok = qt.Check(tt, qt.NoZeroFieldsDeep(value{}, "First.Missing"))
+	assertBool(t, ok, false)
+	assertPrefix(t, tt.errorString(), "\nerror:\n  bad check: ignored field \"First.Missing\" not found in qt_test.value\n")
+}
+
+func TestUnchangedCyclicValue(t *testing.T) {
+	type node struct {
+		Value int
+		Next  *node
+	}
+	n := &node{Value: 1}
+	n.Next = &node{Value: 2, Next: n}
+
+	tt := &testingT{}
+	ok := qt.Check(tt, qt.Unchanged(&n, func() {}))
+	checkResult(t, ok, tt.errorString(), "")
+
+	tt = &testingT{}
+	ok = qt.Check(tt, qt.Unchanged(&n, func() { n.Next.Value++ }))
+	assertBool(t, ok, false)
+}
+
+// jsonLineDiff returns the line diff reported by JSONEquals for the given
+// indented JSON texts.
+func jsonLineDiff(got, want string) string {
+	return diff(strings.SplitAfter(got, "\n"), strings.SplitAfter(want, "\n"))
+}
+
 func diff(got, want any, opts ...cmp.Option) string {
 	d := cmp.Diff(want, got, opts...)
 	return strings.TrimSuffix(qt.Prefixf("  ", "%s", d), "\n")
@@ -2039,3 +7117,20 @@ func mustJSONUnmarshalErr(s string) error {
 func tilde2bq(s string) string {
 	return strings.Replace(s, "~", "`", -1)
 }
+
+// bytesSeq returns a slice of n bytes holding the sequence 0, 1, 2...
+// Optional pairs of index and value arguments override single bytes.
+func bytesSeq(n int, overrides ...int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i)
+	}
+	for i := 0; i+1 < len(overrides); i += 2 {
+		b[overrides[i]] = byte(overrides[i+1])
+	}
+	return b
+}
+
+func jsonDecode(r io.Reader, v any) error {
+	return json.NewDecoder(r).Decode(v)
+}
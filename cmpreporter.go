@@ -0,0 +1,79 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package qt
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// diffReporter is a cmp.Reporter that records the first path at which two
+// non-nil functions are compared, and the path of the first difference found
+// when comparing two values, so that both are collected in a single pass.
+// Functions are only equal when both are nil, so a difference in a function
+// is never meaningful.
+type diffReporter struct {
+	path     cmp.Path
+	funcPath cmp.Path
+	// diffPath holds the formatted path of the first difference. The path
+	// is formatted immediately as cmp may reuse path steps.
+	diffPath string
+}
+
+func (r *diffReporter) PushStep(ps cmp.PathStep) {
+	r.path = append(r.path, ps)
+}
+
+func (r *diffReporter) Report(rs cmp.Result) {
+	if rs.Equal() {
+		return
+	}
+	if r.diffPath == "" {
+		r.diffPath = formatPath(r.path)
+	}
+	if r.funcPath != nil {
+		return
+	}
+	vx, vy := r.path.Last().Values()
+	if vx.IsValid() && vy.IsValid() && vx.Kind() == reflect.Func && !vx.IsNil() && !vy.IsNil() {
+		r.funcPath = append(cmp.Path(nil), r.path...)
+	}
+}
+
+func (r *diffReporter) PopStep() {
+	r.path = r.path[:len(r.path)-1]
+}
+
+// hasOption reports whether opt is included in opts, possibly as part of a
+// cmp.Options value.
+func hasOption(opts []cmp.Option, opt cmp.Option) bool {
+	for _, o := range opts {
+		if o, ok := o.(cmp.Options); ok {
+			if hasOption(o, opt) {
+				return true
+			}
+			continue
+		}
+		if o == opt {
+			return true
+		}
+	}
+	return false
+}
+
+// formatPath formats the given path starting from "root", for instance
+// root.Items[3].Labels["env"]. Pointer indirections are omitted.
+func formatPath(p cmp.Path) string {
+	var buf strings.Builder
+	buf.WriteString("root")
+	// The first step is the root value itself.
+	for _, ps := range p[1:] {
+		if _, ok := ps.(cmp.Indirect); ok {
+			continue
+		}
+		buf.WriteString(ps.String())
+	}
+	return buf.String()
+}
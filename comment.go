@@ -15,15 +15,76 @@ func Commentf(format string, args ...any) Comment {
 	}
 }
 
+// CommentLazy returns a test comment whose output is produced by calling f.
+// The function is only called when the check or assertion fails, so it can be
+// used to avoid the cost of building an expensive message in the common case.
+func CommentLazy(f func() string) Comment {
+	return Comment{
+		f: f,
+	}
+}
+
+// Note returns a test comment that adds the given key and value to the
+// failure output. The value is formatted in the same way as values noted by
+// checkers, so it is useful for attaching relevant inputs to an assertion
+// without including them in a format string:
+//
+//	qt.Assert(t, qt.IsNil(err), qt.Note("request", req))
+func Note(key string, value any) Comment {
+	return Comment{
+		note: &note{
+			key:   key,
+			value: value,
+		},
+	}
+}
+
+// Verbose returns a test comment that forces verbose output for the check or
+// assertion it is provided to, as if tests were run with the -v flag. This is
+// useful for including the full values of a single assertion in the failure
+// output, without them being suppressed because they are too long.
+func Verbose() Comment {
+	return Comment{
+		verbose: true,
+	}
+}
+
+// Redact returns a test comment that masks the given values in the failure
+// output of the check or assertion it is provided to, so that for instance
+// secrets do not end up in CI logs. Values formatted like one of the given
+// values are shown as "<redacted>", and so are occurrences of given strings
+// and byte slices in any part of the output, including diffs:
+//
+//	qt.Assert(t, qt.Equals(token, want), qt.Redact(token, want))
+//
+// Checkers still use the real values when performing the check.
+func Redact(values ...any) Comment {
+	return Comment{
+		redacted: values,
+	}
+}
+
 // Comment represents additional information on a check or an assertion which is
 // displayed when the check or assertion fails.
 type Comment struct {
-	format string
-	args   []any
+	format   string
+	args     []any
+	f        func() string
+	note     *note
+	verbose  bool
+	redacted []any
 }
 
 // String outputs a string formatted according to the stored format specifier
-// and args.
+// and args, or the result of calling the stored function for lazy comments.
+// Comments created with Note are output as the key followed by the formatted
+// value.
 func (c Comment) String() string {
+	if c.f != nil {
+		return c.f()
+	}
+	if c.note != nil {
+		return c.note.key + ": " + Format(c.note.value)
+	}
 	return fmt.Sprintf(c.format, c.args...)
 }
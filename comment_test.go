@@ -25,3 +25,39 @@ func TestConstantCommentf(t *testing.T) {
 		t.Fatalf("constant comment error:\ngot  %q\nwant %q", comment, expectedComment)
 	}
 }
+
+func TestCommentLazy(t *testing.T) {
+	calls := 0
+	c := qt.CommentLazy(func() string {
+		calls++
+		return "the answer is 42"
+	})
+	if calls != 0 {
+		t.Fatalf("lazy comment function called on construction")
+	}
+	comment := c.String()
+	expectedComment := "the answer is 42"
+	if comment != expectedComment {
+		t.Fatalf("lazy comment error:\ngot  %q\nwant %q", comment, expectedComment)
+	}
+	if calls != 1 {
+		t.Fatalf("lazy comment function called %d times, want 1", calls)
+	}
+}
+
+func TestCommentLazyNotCalledOnSuccess(t *testing.T) {
+	tt := &testingT{}
+	qt.Assert(tt, qt.IsTrue(true), qt.CommentLazy(func() string {
+		t.Fatal("lazy comment function called on success")
+		return ""
+	}))
+}
+
+func TestNote(t *testing.T) {
+	c := qt.Note("input", []int{1, 2})
+	comment := c.String()
+	expectedComment := "input: []int{1, 2}"
+	if comment != expectedComment {
+		t.Fatalf("note comment error:\ngot  %q\nwant %q", comment, expectedComment)
+	}
+}
@@ -0,0 +1,86 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package qt
+
+import (
+	"sync"
+	"testing"
+)
+
+// Counter runs checks like Assert and Check do, and counts them, so that a
+// test can verify that it actually ran the checks it was expected to run.
+// This guards for instance against table-driven tests that silently iterate
+// over zero cases.
+//
+// For instance:
+//
+//	c := qt.NewCounter(t)
+//	defer c.Expect(len(tests))
+//	for _, test := range tests {
+//		c.Check(qt.Equals(f(test.in), test.want))
+//	}
+//
+// A Counter can be used concurrently by multiple goroutines.
+type Counter struct {
+	t testing.TB
+
+	mu sync.Mutex
+	n  int
+}
+
+// NewCounter returns a Counter running checks as part of the given test.
+func NewCounter(t testing.TB) *Counter {
+	return &Counter{t: t}
+}
+
+// Assert is like the Assert function, but it also increments the number of
+// checks run through c.
+func (c *Counter) Assert(checker Checker, comments ...Comment) bool {
+	c.t.Helper()
+	c.inc()
+	return check(c.t, checkParams{
+		fail:     c.t.Fatal,
+		checker:  checker,
+		comments: comments,
+	})
+}
+
+// Check is like the Check function, but it also increments the number of
+// checks run through c.
+func (c *Counter) Check(checker Checker, comments ...Comment) bool {
+	c.t.Helper()
+	c.inc()
+	return check(c.t, checkParams{
+		fail:     c.t.Error,
+		checker:  checker,
+		comments: comments,
+	})
+}
+
+// Count returns the number of checks run through c so far, including the
+// ones that failed.
+func (c *Counter) Count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.n
+}
+
+// Expect checks that exactly n checks have been run through c, and calls
+// tb.Error otherwise. It is usually deferred right after creating the
+// counter.
+func (c *Counter) Expect(n int) bool {
+	c.t.Helper()
+	return check(c.t, checkParams{
+		fail:    c.t.Error,
+		checker: Equals(c.Count(), n),
+		comments: []Comment{
+			Commentf("unexpected number of checks run"),
+		},
+	})
+}
+
+func (c *Counter) inc() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.n++
+}
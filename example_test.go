@@ -1,13 +1,26 @@
 package qt_test
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"math"
+	"math/rand"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/go-quicktest/qt"
 	"github.com/google/go-cmp/cmp"
@@ -22,6 +35,20 @@ func ExampleComment() {
 	// Output: PASS
 }
 
+func ExampleRun() {
+	// Run can be used outside of tests.
+	err := qt.Run(qt.Equals(42, 42))
+	fmt.Println(err)
+	// Output: <nil>
+}
+
+func ExampleMust() {
+	// Must can be used outside of tests, for instance in TestMain.
+	qt.Must(qt.Equals(42, 42))
+	fmt.Println("PASS")
+	// Output: PASS
+}
+
 func ExampleEquals() {
 	runExampleTest(func(t testing.TB) {
 		answer := int64(42)
@@ -68,6 +95,91 @@ func ExampleCmpEquals_customfunc() {
 	// Output: PASS
 }
 
+func ExampleCmpTimeEqual() {
+	runExampleTest(func(t testing.TB) {
+		type event struct {
+			Name string
+			At   time.Time
+		}
+		now := time.Now()
+		got := event{Name: "start", At: now}
+		qt.Assert(t, qt.CmpEquals(got, event{Name: "start", At: now.UTC()}, qt.CmpTimeEqual))
+	})
+	// Output: PASS
+}
+
+func ExampleDeepEqualsEquatingEmpty() {
+	runExampleTest(func(t testing.TB) {
+		type response struct {
+			Items []string
+		}
+		var got response
+		qt.Assert(t, qt.DeepEqualsEquatingEmpty(got, response{Items: []string{}}))
+	})
+	// Output: PASS
+}
+
+func ExampleDeepEqualsApprox() {
+	runExampleTest(func(t testing.TB) {
+		got := map[string][]float64{
+			"sums":  {0.1 + 0.2},
+			"roots": {math.Sqrt(2)},
+		}
+		qt.Assert(t, qt.DeepEqualsApprox(got, map[string][]float64{
+			"sums":  {0.3},
+			"roots": {1.41421356},
+		}, 0, 1e-8))
+	})
+	// Output: PASS
+}
+
+func ExampleDeepEqualsIgnoring() {
+	runExampleTest(func(t testing.TB) {
+		type Metadata struct {
+			Owner   string
+			Created time.Time
+		}
+		type Record struct {
+			ID   int
+			Name string
+			Meta Metadata
+		}
+		got := []Record{{
+			ID:   42,
+			Name: "foo",
+			Meta: Metadata{Owner: "bob", Created: time.Now()},
+		}}
+		want := []Record{{
+			Name: "foo",
+			Meta: Metadata{Owner: "bob"},
+		}}
+		qt.Assert(t, qt.DeepEqualsIgnoring(got, want, "ID", "Meta.Created"))
+	})
+	// Output: PASS
+}
+
+func ExampleFieldEquals() {
+	runExampleTest(func(t testing.TB) {
+		type Address struct {
+			City string
+		}
+		type User struct {
+			Name      string
+			Addresses []Address
+		}
+		resp := struct {
+			User *User
+		}{
+			User: &User{
+				Name:      "bob",
+				Addresses: []Address{{City: "London"}, {City: "Rome"}},
+			},
+		}
+		qt.Assert(t, qt.FieldEquals(resp, "User.Addresses[1].City", "Rome"))
+	})
+	// Output: PASS
+}
+
 func ExampleContentEquals() {
 	runExampleTest(func(t testing.TB) {
 		got := []int{1, 23, 4, 5}
@@ -76,6 +188,186 @@ func ExampleContentEquals() {
 	// Output: PASS
 }
 
+func ExampleOneOf() {
+	runExampleTest(func(t testing.TB) {
+		status := "running"
+		qt.Assert(t, qt.OneOf(status, "pending", "running", "done"))
+	})
+	// Output: PASS
+}
+
+func ExampleDeepOneOf() {
+	runExampleTest(func(t testing.TB) {
+		// The order of the results is not deterministic.
+		results := []string{"b", "a"}
+		qt.Assert(t, qt.DeepOneOf(results, []string{"a", "b"}, []string{"b", "a"}))
+	})
+	// Output: PASS
+}
+
+func ExampleSetEquals() {
+	runExampleTest(func(t testing.TB) {
+		tags := []string{"go", "testing", "go"}
+		qt.Assert(t, qt.SetEquals(tags, []string{"testing", "go"}))
+	})
+	// Output: PASS
+}
+
+func ExampleIsPermutationOf() {
+	runExampleTest(func(t testing.TB) {
+		deck := []string{"A", "K", "Q", "J"}
+		shuffled := append([]string(nil), deck...)
+		rand.Shuffle(len(shuffled), func(i, j int) {
+			shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+		})
+		qt.Assert(t, qt.IsPermutationOf(shuffled, deck))
+	})
+	// Output: PASS
+}
+
+func ExampleIsPermutation() {
+	runExampleTest(func(t testing.TB) {
+		qt.Assert(t, qt.IsPermutation(rand.Perm(10)))
+	})
+	// Output: PASS
+}
+
+func ExampleMapKeys() {
+	runExampleTest(func(t testing.TB) {
+		headers := map[string]string{
+			"Content-Type":   "application/json",
+			"Content-Length": "42",
+		}
+		qt.Assert(t, qt.MapKeys(headers, []string{"Content-Length", "Content-Type"}))
+	})
+	// Output: PASS
+}
+
+func ExampleMapSubset() {
+	runExampleTest(func(t testing.TB) {
+		labels := map[string]string{"app": "web", "tier": "frontend"}
+		qt.Assert(t, qt.MapSubset(labels, map[string]string{
+			"app":     "web",
+			"tier":    "frontend",
+			"version": "v1",
+		}))
+	})
+	// Output: PASS
+}
+
+func ExampleMapEqualsIgnoring() {
+	runExampleTest(func(t testing.TB) {
+		headers := map[string]string{
+			"Content-Type": "application/json",
+			"Date":         time.Now().Format(time.RFC1123),
+		}
+		qt.Assert(t, qt.MapEqualsIgnoring(headers, map[string]string{
+			"Content-Type": "application/json",
+		}, "Date"))
+	})
+	// Output: PASS
+}
+
+func ExampleNoDuplicates() {
+	runExampleTest(func(t testing.TB) {
+		ids := []int{4, 8, 15, 16, 23, 42}
+		qt.Assert(t, qt.NoDuplicates(ids))
+	})
+	// Output: PASS
+}
+
+func ExampleUniqueBy() {
+	runExampleTest(func(t testing.TB) {
+		type user struct {
+			ID   int
+			Tags []string
+		}
+		users := []user{{ID: 1}, {ID: 2, Tags: []string{"admin"}}, {ID: 3}}
+		qt.Assert(t, qt.UniqueBy(users, func(u user) int { return u.ID }))
+	})
+	// Output: PASS
+}
+
+func ExampleSliceCount() {
+	runExampleTest(func(t testing.TB) {
+		events := []string{"start", "error", "retry", "error", "done"}
+		qt.Assert(t, qt.SliceCount(events, "error", 2))
+	})
+	// Output: PASS
+}
+
+func ExampleMappedEquals() {
+	runExampleTest(func(t testing.TB) {
+		type user struct {
+			Name string
+			Age  int
+		}
+		users := []user{{"alice", 30}, {"bob", 25}}
+		qt.Assert(t, qt.MappedEquals(users, func(u user) string { return u.Name }, []string{"alice", "bob"}))
+	})
+	// Output: PASS
+}
+
+func ExampleSliceStartsWith() {
+	runExampleTest(func(t testing.TB) {
+		args := []string{"go", "test", "-v", "./..."}
+		qt.Assert(t, qt.SliceStartsWith(args, []string{"go", "test"}))
+	})
+	// Output: PASS
+}
+
+func ExampleSliceEndsWith() {
+	runExampleTest(func(t testing.TB) {
+		events := []string{"start", "retry", "done"}
+		qt.Assert(t, qt.SliceEndsWith(events, []string{"done"}))
+	})
+	// Output: PASS
+}
+
+func ExampleIsSubsequence() {
+	runExampleTest(func(t testing.TB) {
+		allowed := []string{"init", "connect", "send", "receive", "close"}
+		observed := []string{"init", "send", "close"}
+		qt.Assert(t, qt.IsSubsequence(observed, allowed))
+	})
+	// Output: PASS
+}
+
+func ExampleIsStrictlyIncreasing() {
+	runExampleTest(func(t testing.TB) {
+		qt.Assert(t, qt.IsStrictlyIncreasing([]int{1, 2, 3, 5, 8}))
+		qt.Assert(t, qt.Not(qt.IsStrictlyIncreasing([]int{1, 1, 2})))
+	})
+	// Output: PASS
+}
+
+func ExampleIsStrictlyDecreasing() {
+	runExampleTest(func(t testing.TB) {
+		qt.Assert(t, qt.IsStrictlyDecreasing([]string{"c", "b", "a"}))
+	})
+	// Output: PASS
+}
+
+func ExampleLessFunc() {
+	runExampleTest(func(t testing.TB) {
+		type version struct {
+			Major, Minor int
+		}
+		compare := func(a, b version) int {
+			if a.Major != b.Major {
+				return a.Major - b.Major
+			}
+			return a.Minor - b.Minor
+		}
+		qt.Assert(t, qt.LessFunc(version{1, 9}, version{1, 10}, compare))
+		qt.Assert(t, qt.GreaterFunc(version{2, 0}, version{1, 10}, compare))
+		qt.Assert(t, qt.EqualFunc("Go", "go", func(a, b string) int {
+			return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+		}))
+	})
+	// Output: PASS
+}
+
 func ExampleMatches() {
 	runExampleTest(func(t testing.TB) {
 		qt.Assert(t, qt.Matches("these are the voyages", "these are .*"))
@@ -84,6 +376,40 @@ func ExampleMatches() {
 	// Output: PASS
 }
 
+func ExampleMatchesPart() {
+	runExampleTest(func(t testing.TB) {
+		qt.Assert(t, qt.MatchesPart("these are the voyages", "the v[a-z]+"))
+	})
+	// Output: PASS
+}
+
+func ExampleMatchesNamed() {
+	runExampleTest(func(t testing.TB) {
+		logLine := "2024-01-02 ERROR disk full"
+		re := regexp.MustCompile(`(?P<date>[0-9-]+) (?P<level>[A-Z]+) (?P<msg>.*)`)
+		qt.Assert(t, qt.MatchesNamed(logLine, re, map[string]string{
+			"date":  "2024-01-02",
+			"level": "ERROR",
+			"msg":   "disk full",
+		}))
+	})
+	// Output: PASS
+}
+
+func ExampleStringEquals() {
+	runExampleTest(func(t testing.TB) {
+		qt.Assert(t, qt.StringEquals(os.ModeDir|0o755, "drwxr-xr-x"))
+	})
+	// Output: PASS
+}
+
+func ExampleStringMatches() {
+	runExampleTest(func(t testing.TB) {
+		qt.Assert(t, qt.StringMatches(1500*time.Millisecond, `[\d.]+s`))
+	})
+	// Output: PASS
+}
+
 func ExampleErrorMatches() {
 	runExampleTest(func(t testing.TB) {
 		err := errors.New("bad wolf at the door")
@@ -92,6 +418,30 @@ func ExampleErrorMatches() {
 	// Output: PASS
 }
 
+func ExampleErrorChainMatches() {
+	runExampleTest(func(t testing.TB) {
+		err := fmt.Errorf("handler failed: %w", errors.New("bad wolf at the door"))
+		qt.Assert(t, qt.ErrorChainMatches(err, "bad wolf .*"))
+	})
+	// Output: PASS
+}
+
+func ExampleErrorChainTypes() {
+	runExampleTest(func(t testing.TB) {
+		err := fmt.Errorf("cannot load configuration: %w", &fs.PathError{
+			Op:   "open",
+			Path: "config.yaml",
+			Err:  fs.ErrNotExist,
+		})
+		qt.Assert(t, qt.ErrorChainTypes(err,
+			reflect.TypeOf(fmt.Errorf("%w", err)),
+			reflect.TypeOf((*fs.PathError)(nil)),
+			reflect.TypeOf(fs.ErrNotExist),
+		))
+	})
+	// Output: PASS
+}
+
 func ExamplePanicMatches() {
 	runExampleTest(func(t testing.TB) {
 		divide := func(a, b int) int {
@@ -138,6 +488,23 @@ func ExampleHasLen() {
 			"c": 10,
 		}
 		qt.Assert(t, qt.HasLen(myMap, 3))
+
+		// The length of the dynamic value of an interface is checked.
+		var v any = []string{"a", "b"}
+		qt.Assert(t, qt.HasLen(v, 2))
+	})
+	// Output: PASS
+}
+
+func ExampleChannelLen() {
+	runExampleTest(func(t testing.TB) {
+		jobs := make(chan string, 3)
+		qt.Assert(t, qt.ChannelEmpty(jobs))
+		jobs <- "build"
+		jobs <- "test"
+		qt.Assert(t, qt.ChannelLen(jobs, 2))
+		jobs <- "deploy"
+		qt.Assert(t, qt.ChannelFull(jobs))
 	})
 	// Output: PASS
 }
@@ -152,6 +519,18 @@ func ExampleImplements() {
 	// Output: PASS
 }
 
+func ExampleImplementsAll() {
+	runExampleTest(func(t testing.TB) {
+		f := strings.NewReader("hello")
+		qt.Assert(t, qt.ImplementsAll(f,
+			reflect.TypeOf((*io.Reader)(nil)).Elem(),
+			reflect.TypeOf((*io.Seeker)(nil)).Elem(),
+			reflect.TypeOf((*io.ReaderAt)(nil)).Elem(),
+		))
+	})
+	// Output: PASS
+}
+
 func ExampleSatisfies() {
 	runExampleTest(func(t testing.TB) {
 		// Check that an error from os.Open satisfies os.IsNotExist.
@@ -166,6 +545,52 @@ func ExampleSatisfies() {
 	// Output: PASS
 }
 
+func ExampleSatisfiesErr() {
+	runExampleTest(func(t testing.TB) {
+		validatePort := func(port int) error {
+			if port <= 0 || port > 65535 {
+				return fmt.Errorf("port %d out of range", port)
+			}
+			return nil
+		}
+		qt.Assert(t, qt.SatisfiesErr(8080, validatePort))
+	})
+	// Output: PASS
+}
+
+func ExampleChanges() {
+	runExampleTest(func(t testing.TB) {
+		var name string
+		setName := func() {
+			name = "bob"
+		}
+		qt.Assert(t, qt.Changes(&name, setName, "bob"))
+	})
+	// Output: PASS
+}
+
+func ExampleUnchanged() {
+	runExampleTest(func(t testing.TB) {
+		config := map[string][]string{"hosts": {"a", "b"}}
+		lookup := func(key string) []string {
+			return config[key]
+		}
+		qt.Assert(t, qt.Unchanged(&config, func() { lookup("hosts") }))
+	})
+	// Output: PASS
+}
+
+func ExampleIncrements() {
+	runExampleTest(func(t testing.TB) {
+		var hits int
+		hit := func() {
+			hits++
+		}
+		qt.Assert(t, qt.Increments(&hits, hit, 1))
+	})
+	// Output: PASS
+}
+
 func ExampleIsTrue() {
 	runExampleTest(func(t testing.TB) {
 		isValid := func() bool {
@@ -190,6 +615,113 @@ func ExampleIsFalse() {
 
 }
 
+func ExampleIsPositive() {
+	runExampleTest(func(t testing.TB) {
+		start := time.Now()
+		time.Sleep(time.Millisecond)
+		qt.Assert(t, qt.IsPositive(time.Since(start)))
+	})
+	// Output: PASS
+}
+
+func ExampleIsNegative() {
+	runExampleTest(func(t testing.TB) {
+		qt.Assert(t, qt.IsNegative(strings.Compare("a", "b")))
+	})
+	// Output: PASS
+}
+
+func ExampleIsNonNegative() {
+	runExampleTest(func(t testing.TB) {
+		qt.Assert(t, qt.IsNonNegative(strings.Index("hello", "h")))
+	})
+	// Output: PASS
+}
+
+func ExampleIsMultipleOf() {
+	runExampleTest(func(t testing.TB) {
+		bufSize := 16 * os.Getpagesize()
+		qt.Assert(t, qt.IsMultipleOf(bufSize, os.Getpagesize()))
+	})
+	// Output: PASS
+}
+
+func ExampleApproximatelyPercent() {
+	runExampleTest(func(t testing.TB) {
+		// The measured throughput must be within 10% of the expected one.
+		throughput := 1040.0
+		qt.Assert(t, qt.ApproximatelyPercent(throughput, 1000, 10))
+	})
+	// Output: PASS
+}
+
+func ExampleSliceApproximately() {
+	runExampleTest(func(t testing.TB) {
+		got := []float64{0.1 + 0.2, math.Sqrt(2)}
+		qt.Assert(t, qt.SliceApproximately(got, []float64{0.3, 1.41421356}, 1e-8))
+	})
+	// Output: PASS
+}
+
+func ExampleWithinDuration() {
+	runExampleTest(func(t testing.TB) {
+		createdAt := time.Now()
+		qt.Assert(t, qt.WithinDuration(createdAt, time.Now(), time.Minute))
+	})
+	// Output: PASS
+}
+
+func ExampleTimeEquals() {
+	runExampleTest(func(t testing.TB) {
+		now := time.Now()
+		// Round(0) strips the monotonic clock reading, which would make
+		// DeepEquals fail.
+		qt.Assert(t, qt.TimeEquals(now.Round(0).UTC(), now))
+	})
+	// Output: PASS
+}
+
+func ExampleEqualsMethod() {
+	runExampleTest(func(t testing.TB) {
+		ip := net.ParseIP("192.168.0.1")
+		qt.Assert(t, qt.EqualsMethod(ip, net.IPv4(192, 168, 0, 1)))
+	})
+	// Output: PASS
+}
+
+func ExampleInTheFuture() {
+	runExampleTest(func(t testing.TB) {
+		expiry := time.Now().Add(time.Hour)
+		qt.Assert(t, qt.InTheFuture(expiry))
+	})
+	// Output: PASS
+}
+
+func ExampleInThePast() {
+	runExampleTest(func(t testing.TB) {
+		created := time.Now().Add(-time.Minute)
+		qt.Assert(t, qt.InThePast(created))
+	})
+	// Output: PASS
+}
+
+func ExampleDurationEquals() {
+	runExampleTest(func(t testing.TB) {
+		timeout := 90 * time.Second
+		qt.Assert(t, qt.DurationEquals(timeout, time.Minute+30*time.Second))
+	})
+	// Output: PASS
+}
+
+func ExampleDurationWithin() {
+	runExampleTest(func(t testing.TB) {
+		start := time.Now()
+		time.Sleep(10 * time.Millisecond)
+		qt.Assert(t, qt.DurationWithin(time.Since(start), 10*time.Millisecond, time.Second))
+	})
+	// Output: PASS
+}
+
 func ExampleNot() {
 	runExampleTest(func(t testing.TB) {
 
@@ -202,6 +734,18 @@ func ExampleNot() {
 	// Output: PASS
 }
 
+func ExampleAll() {
+	runExampleTest(func(t testing.TB) {
+		got := []int{1, 2}
+		qt.Assert(t, qt.All(
+			qt.HasLen(got, 2),
+			qt.Equals(got[0], 1),
+			qt.Equals(got[1], 2),
+		))
+	})
+	// Output: PASS
+}
+
 func ExampleStringContains() {
 	runExampleTest(func(t testing.TB) {
 		qt.Assert(t, qt.StringContains("hello world", "hello"))
@@ -209,6 +753,76 @@ func ExampleStringContains() {
 	// Output: PASS
 }
 
+func ExampleEqualsTrimmed() {
+	runExampleTest(func(t testing.TB) {
+		output := "  hello world\n\n"
+		qt.Assert(t, qt.EqualsTrimmed(output, "hello world"))
+	})
+	// Output: PASS
+}
+
+func ExampleIsValidUTF8() {
+	runExampleTest(func(t testing.TB) {
+		sanitized := strings.ToValidUTF8("caf\xe9", "?")
+		qt.Assert(t, qt.IsValidUTF8(sanitized))
+	})
+	// Output: PASS
+}
+
+func ExampleBytesEquals() {
+	runExampleTest(func(t testing.TB) {
+		got := []byte{0xca, 0xfe, 0xba, 0xbe}
+		qt.Assert(t, qt.BytesEquals(got, []byte{0xca, 0xfe, 0xba, 0xbe}))
+	})
+	// Output: PASS
+}
+
+func ExampleReaderEquals() {
+	runExampleTest(func(t testing.TB) {
+		got := strings.NewReader(strings.ToUpper("hello\nworld\n"))
+		qt.Assert(t, qt.ReaderEquals(got, strings.NewReader("HELLO\nWORLD\n")))
+	})
+	// Output: PASS
+}
+
+func ExampleBytesContains() {
+	runExampleTest(func(t testing.TB) {
+		got := []byte("\x89PNG\r\n\x1a\n\x00\x00")
+		qt.Assert(t, qt.BytesContains(got, []byte("PNG")))
+	})
+	// Output: PASS
+}
+
+func ExampleContains() {
+	runExampleTest(func(t testing.TB) {
+		containers := []any{
+			"hello world",
+			[]string{"hello", "world"},
+			map[int]string{1: "hello", 2: "world"},
+		}
+		for _, container := range containers {
+			qt.Assert(t, qt.Contains(container, "world"))
+		}
+	})
+	// Output: PASS
+}
+
+func ExampleFSEquals() {
+	runExampleTest(func(t testing.TB) {
+		// Generate some files in a temporary directory.
+		dir, err := os.MkdirTemp("", "qt-example")
+		qt.Assert(t, qt.IsNil(err))
+		defer os.RemoveAll(dir)
+		err = os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0o644)
+		qt.Assert(t, qt.IsNil(err))
+
+		qt.Assert(t, qt.FSEquals(os.DirFS(dir), fstest.MapFS{
+			"main.go": {Data: []byte("package main\n")},
+		}))
+	})
+	// Output: PASS
+}
+
 func ExampleSliceContains() {
 	runExampleTest(func(t testing.TB) {
 		qt.Assert(t, qt.SliceContains([]int{3, 5, 7, 99}, 99))
@@ -261,6 +875,89 @@ func ExampleMapAll() {
 	// Output: PASS
 }
 
+func ExampleSliceCountMatching() {
+	runExampleTest(func(t testing.TB) {
+		logs := []string{"ERROR: disk full", "INFO: retrying", "ERROR: disk full", "ERROR: giving up"}
+		qt.Assert(t, qt.SliceCountMatching(logs, qt.F2(qt.Matches[string], "ERROR: .*"), 3))
+	})
+	// Output: PASS
+}
+
+func ExampleSliceMatches() {
+	runExampleTest(func(t testing.TB) {
+		record := []string{"bob", "bob@example.com", "42"}
+		qt.Assert(t, qt.SliceMatches(record, []func(string) qt.Checker{
+			qt.F2(qt.Equals[string], "bob"),
+			qt.F2(qt.Matches[string], ".+@example.com"),
+			func(s string) qt.Checker {
+				return qt.Not(qt.Equals(s, ""))
+			},
+		}))
+	})
+	// Output: PASS
+}
+
+func ExampleRepeatedly() {
+	runExampleTest(func(t testing.TB) {
+		cache := make(map[string]int)
+		lookup := func(key string) int {
+			if v, ok := cache[key]; ok {
+				return v
+			}
+			cache[key] = len(cache) + 1
+			return cache[key]
+		}
+		qt.Assert(t, qt.Repeatedly(5, func() qt.Checker {
+			return qt.Equals(lookup("answer"), 1)
+		}))
+	})
+	// Output: PASS
+}
+
+func ExampleCounter() {
+	runExampleTest(func(t testing.TB) {
+		tests := []struct {
+			in   string
+			want string
+		}{
+			{"hello", "HELLO"},
+			{"World", "WORLD"},
+		}
+		c := qt.NewCounter(t)
+		defer c.Expect(len(tests))
+		for _, test := range tests {
+			c.Check(qt.Equals(strings.ToUpper(test.in), test.want))
+		}
+	})
+	// Output: PASS
+}
+
+func ExampleAlwaysWithin() {
+	runExampleTest(func(t testing.TB) {
+		var (
+			mu      sync.Mutex
+			balance = 100
+		)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 1000; i++ {
+				mu.Lock()
+				balance -= 10
+				balance += 10
+				mu.Unlock()
+			}
+		}()
+		qt.Assert(t, qt.AlwaysWithin(func() int {
+			mu.Lock()
+			defer mu.Unlock()
+			return balance
+		}, 100, 100, 10*time.Millisecond))
+		<-done
+	})
+	// Output: PASS
+}
+
 func ExampleJSONEquals() {
 	runExampleTest(func(t testing.TB) {
 		data := `[1, 2, 3]`
@@ -269,6 +966,57 @@ func ExampleJSONEquals() {
 	// Output: PASS
 }
 
+func ExampleJSONEqualsUnordered() {
+	runExampleTest(func(t testing.TB) {
+		data := `{"name": "bob", "groups": ["staff", "admin"]}`
+		qt.Assert(t, qt.JSONEqualsUnordered(data, map[string]any{
+			"name":   "bob",
+			"groups": []string{"admin", "staff"},
+		}))
+	})
+	// Output: PASS
+}
+
+func ExampleIsValidJSON() {
+	runExampleTest(func(t testing.TB) {
+		data := fmt.Sprintf(`{"name": %q, "tags": [%q, %q]}`, "bob", "a", "b")
+		qt.Assert(t, qt.IsValidJSON(data))
+	})
+	// Output: PASS
+}
+
+func ExampleCodecEqualsReader() {
+	runExampleTest(func(t testing.TB) {
+		// The reader could be, for instance, the body of an HTTP response.
+		body := strings.NewReader(`{"name": "bob", "age": 42}`)
+		decode := func(r io.Reader, v any) error {
+			return json.NewDecoder(r).Decode(v)
+		}
+		want := map[string]any{"name": "bob", "age": 42}
+		qt.Assert(t, qt.CodecEqualsReader(body, want, json.Marshal, decode))
+	})
+	// Output: PASS
+}
+
+func ExampleErrorHasMessage() {
+	runExampleTest(func(t testing.TB) {
+		err := errors.New("cannot open file (permission denied).")
+		qt.Assert(t, qt.ErrorHasMessage(err, "cannot open file (permission denied)."))
+	})
+	// Output: PASS
+}
+
+func ExampleJSONRoundTrips() {
+	runExampleTest(func(t testing.TB) {
+		type config struct {
+			Name    string        `json:"name"`
+			Timeout time.Duration `json:"timeout"`
+		}
+		qt.Assert(t, qt.JSONRoundTrips(config{Name: "server", Timeout: time.Minute}))
+	})
+	// Output: PASS
+}
+
 func ExampleErrorAs() {
 	runExampleTest(func(t testing.TB) {
 		_, err := os.Open("/non-existent-file")
@@ -286,6 +1034,84 @@ func ExampleErrorAs() {
 	// Output: PASS
 }
 
+func ExampleHasErrorType() {
+	runExampleTest(func(t testing.TB) {
+		_, err := os.Open("/non-existent-file")
+		qt.Assert(t, qt.HasErrorType[*os.PathError](err))
+	})
+	// Output: PASS
+}
+
+func ExampleContextDone() {
+	runExampleTest(func(t testing.TB) {
+		ctx, cancel := context.WithCancel(context.Background())
+		qt.Assert(t, qt.ContextNotDone(ctx))
+		cancel()
+		qt.Assert(t, qt.ContextDone(ctx))
+	})
+	// Output: PASS
+}
+
+func ExampleStatusCode() {
+	runExampleTest(func(t testing.TB) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"id": 42}`)
+		}
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest("GET", "/", nil))
+		resp := rec.Result()
+		qt.Assert(t, qt.StatusCode(resp, http.StatusOK))
+		qt.Assert(t, qt.HeaderEquals(resp, "Content-Type", "application/json"))
+		qt.Assert(t, qt.BodyMatches(resp, `\{"id": \d+\}`))
+	})
+	// Output: PASS
+}
+
+func ExampleWaitGroupDone() {
+	runExampleTest(func(t testing.TB) {
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+			}()
+		}
+		qt.Assert(t, qt.WaitGroupDone(&wg, 5*time.Second))
+	})
+	// Output: PASS
+}
+
+func ExampleIsContextCanceled() {
+	runExampleTest(func(t testing.TB) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		qt.Assert(t, qt.IsContextCanceled(ctx.Err()))
+	})
+	// Output: PASS
+}
+
+func ExampleIsContextDeadlineExceeded() {
+	runExampleTest(func(t testing.TB) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+		defer cancel()
+		<-ctx.Done()
+		qt.Assert(t, qt.IsContextDeadlineExceeded(ctx.Err()))
+	})
+	// Output: PASS
+}
+
+func ExampleAssertError() {
+	runExampleTest(func(t testing.TB) {
+		_, err := os.Stat(os.TempDir())
+		qt.AssertError(t, err, nil)
+
+		_, err = os.Open("/non-existent-file")
+		qt.AssertError(t, err, os.ErrNotExist)
+	})
+	// Output: PASS
+}
+
 func ExampleErrorIs() {
 	runExampleTest(func(t testing.TB) {
 		_, err := os.Open("/non-existent-file")
@@ -295,6 +1121,33 @@ func ExampleErrorIs() {
 	// Output: PASS
 }
 
+func ExampleNewChecker() {
+	runExampleTest(func(t testing.TB) {
+		isEven := func(n int) qt.Checker {
+			return qt.NewChecker([]qt.Arg{{Name: "got", Value: n}}, func(note func(key string, value any)) error {
+				if n%2 != 0 {
+					return errors.New("value is not even")
+				}
+				return nil
+			})
+		}
+		qt.Assert(t, isEven(42))
+		qt.Assert(t, qt.Not(isEven(43)))
+	})
+	// Output: PASS
+}
+
+func ExampleErrorEquals() {
+	runExampleTest(func(t testing.TB) {
+		_, err := os.Open("/non-existent-file")
+		qt.Assert(t, qt.ErrorIs(err, os.ErrNotExist))
+		// The error wraps os.ErrNotExist, but it is not equivalent to it.
+		qt.Assert(t, qt.Not(qt.ErrorEquals(err, os.ErrNotExist)))
+		qt.Assert(t, qt.ErrorEquals(os.ErrNotExist, fs.ErrNotExist))
+	})
+	// Output: PASS
+}
+
 func runExampleTest(f func(t testing.TB)) {
 	defer func() {
 		if err := recover(); err != nil && err != exampleTestFatal {
@@ -3,6 +3,10 @@
 package qt
 
 var (
-	Prefixf        = prefixf
-	TestingVerbose = &testingVerbose
+	CmpOptions           = &cmpOptions
+	GoSource             = goSource
+	GoroutineLeakTimeout = &goroutineLeakTimeout
+	Prefixf              = prefixf
+	TestingVerbose       = &testingVerbose
+	TimeNow              = &timeNow
 )
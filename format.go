@@ -19,7 +19,9 @@ func Format(v any) string {
 	case error:
 		s, ok := checkStringCall(v, v.Error)
 		if !ok {
-			return "e<nil>"
+			// Include the concrete type so that it is clear that the error
+			// is a non-nil interface wrapping a nil pointer.
+			return fmt.Sprintf("e(%T)(nil)", v)
 		}
 		if msg := fmt.Sprintf("%+v", v); msg != s {
 			// The error has formatted itself with additional information.
@@ -45,7 +45,7 @@ var formatTests = []struct {
 }, {
 	about: "error value: not guarding against nil",
 	value: (*errTest)(nil),
-	want:  `e<nil>`,
+	want:  `e(*qt_test.errTest)(nil)`,
 }, {
 	about: "stringer",
 	value: bytes.NewBufferString("I am a stringer"),
@@ -0,0 +1,263 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package qt
+
+import (
+	"fmt"
+	"go/format"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// goSource returns v rendered as a gofmt'd Go expression which, when
+// compiled, produces a value deep equal to v. It is used to report obtained
+// values in a form that can be copied into tests.
+//
+// Type names are qualified with the name of their package, and channels and
+// non-nil functions cannot be rendered: they are reported as nil with a
+// comment. The same applies to the references that make a value cyclic.
+func goSource(v any) (string, error) {
+	r := goSourceRenderer{
+		visiting: make(map[visitKey]bool),
+	}
+	r.render(reflect.ValueOf(v), true, false)
+	const prefix = "var _ = "
+	src, err := format.Source([]byte(prefix + r.buf.String()))
+	if err != nil {
+		return "", fmt.Errorf("cannot format Go source: %v", err)
+	}
+	return strings.TrimPrefix(string(src), prefix), nil
+}
+
+type goSourceRenderer struct {
+	buf strings.Builder
+	// visiting holds the pointers, maps and slices currently being
+	// rendered, so that cyclic values are detected.
+	visiting map[visitKey]bool
+}
+
+// visitKey identifies a pointer, map or slice being rendered. The type is
+// included because a slice and its first element share the same address.
+type visitKey struct {
+	t reflect.Type
+	p uintptr
+}
+
+// enter records that v is being rendered. It reports false, after
+// rendering a nil value, when v is already being rendered, in which case
+// leave must not be called.
+func (r *goSourceRenderer) enter(v reflect.Value, typed bool) bool {
+	k := visitKey{v.Type(), v.Pointer()}
+	if r.visiting[k] {
+		r.renderNil(v.Type(), typed)
+		r.buf.WriteString(" /* cycle */")
+		return false
+	}
+	r.visiting[k] = true
+	return true
+}
+
+func (r *goSourceRenderer) leave(v reflect.Value) {
+	delete(r.visiting, visitKey{v.Type(), v.Pointer()})
+}
+
+// render writes the Go source for v. If typed is true, the expression must
+// carry the type of v, as is the case at top level or in an interface. If
+// elide is true, the type of composite literals can be omitted, as is the
+// case for slice elements for instance.
+func (r *goSourceRenderer) render(v reflect.Value, typed, elide bool) {
+	if !v.IsValid() {
+		r.buf.WriteString("nil")
+		return
+	}
+	t := v.Type()
+	if t == reflect.TypeOf(time.Time{}) && v.CanInterface() {
+		r.renderTime(v.Interface().(time.Time))
+		return
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		r.renderBasic(t, strconv.FormatBool(v.Bool()), typed, "bool")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		r.renderBasic(t, strconv.FormatInt(v.Int(), 10), typed, "int")
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		r.renderBasic(t, strconv.FormatUint(v.Uint(), 10), typed, "")
+	case reflect.Float32, reflect.Float64:
+		r.renderBasic(t, formatFloat(v.Float(), t.Bits()), typed, "float64")
+	case reflect.Complex64, reflect.Complex128:
+		r.renderBasic(t, strconv.FormatComplex(v.Complex(), 'g', -1, t.Bits()), typed, "complex128")
+	case reflect.String:
+		r.renderBasic(t, strconv.Quote(v.String()), typed, "string")
+	case reflect.Interface:
+		// The dynamic type of the value must always be specified.
+		r.render(v.Elem(), true, false)
+	case reflect.Pointer:
+		r.renderPointer(v, typed, elide)
+	case reflect.Slice, reflect.Map:
+		if v.IsNil() {
+			r.renderNil(t, typed)
+			return
+		}
+		if !r.enter(v, typed) {
+			return
+		}
+		defer r.leave(v)
+		r.renderComposite(v, elide)
+	case reflect.Array, reflect.Struct:
+		r.renderComposite(v, elide)
+	default:
+		// Channels, functions and unsafe pointers cannot be rendered.
+		r.renderNil(t, typed)
+		if !v.IsNil() {
+			fmt.Fprintf(&r.buf, " /* non-nil %s */", t)
+		}
+	}
+}
+
+// renderBasic writes the given literal for a value of basic type t. The type
+// is omitted when not required or when t is the default type of the
+// literal, as given by defaultType.
+func (r *goSourceRenderer) renderBasic(t reflect.Type, lit string, typed bool, defaultType string) {
+	if !typed || t.String() == defaultType {
+		r.buf.WriteString(lit)
+		return
+	}
+	fmt.Fprintf(&r.buf, "%s(%s)", t, lit)
+}
+
+func (r *goSourceRenderer) renderNil(t reflect.Type, typed bool) {
+	if !typed {
+		r.buf.WriteString("nil")
+		return
+	}
+	fmt.Fprintf(&r.buf, "(%s)(nil)", t)
+}
+
+func (r *goSourceRenderer) renderPointer(v reflect.Value, typed, elide bool) {
+	if v.IsNil() {
+		r.renderNil(v.Type(), typed)
+		return
+	}
+	if !r.enter(v, typed) {
+		return
+	}
+	defer r.leave(v)
+	elem := v.Elem()
+	switch elem.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		if elem.Type() == reflect.TypeOf(time.Time{}) || (elem.Kind() == reflect.Slice || elem.Kind() == reflect.Map) && elem.IsNil() {
+			break
+		}
+		// The address of composite literals can be taken directly. When
+		// the type can be omitted, so can the & operator.
+		if !elide {
+			r.buf.WriteString("&")
+		}
+		r.renderComposite(elem, elide)
+		return
+	}
+	// Other values must be stored in a variable to take their address.
+	fmt.Fprintf(&r.buf, "func() %s { v := ", v.Type())
+	r.render(elem, true, false)
+	r.buf.WriteString("; return &v }()")
+}
+
+// renderComposite writes a composite literal for the given struct, array,
+// slice or map value.
+func (r *goSourceRenderer) renderComposite(v reflect.Value, elide bool) {
+	t := v.Type()
+	if !elide {
+		r.buf.WriteString(t.String())
+	}
+	var entries []string
+	switch t.Kind() {
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if v.Field(i).IsZero() {
+				continue
+			}
+			// The types of struct fields are known, but the types of
+			// composite literals cannot be omitted.
+			entries = append(entries, t.Field(i).Name+": "+r.sub(v.Field(i), false, false))
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			entries = append(entries, r.sub(iter.Key(), false, true)+": "+r.sub(iter.Value(), false, true))
+		}
+		// Render entries in a deterministic order.
+		sort.Strings(entries)
+	default:
+		for i := 0; i < v.Len(); i++ {
+			entries = append(entries, r.sub(v.Index(i), false, true))
+		}
+	}
+	if t.Kind() != reflect.Struct && isShort(entries) {
+		r.buf.WriteString("{" + strings.Join(entries, ", ") + "}")
+		return
+	}
+	r.buf.WriteString("{")
+	for _, e := range entries {
+		r.buf.WriteString("\n" + e + ",")
+	}
+	if len(entries) > 0 {
+		r.buf.WriteString("\n")
+	}
+	r.buf.WriteString("}")
+}
+
+// isShort reports whether the given composite literal entries are short
+// enough to be rendered on a single line.
+func isShort(entries []string) bool {
+	n := 0
+	for _, e := range entries {
+		if strings.Contains(e, "\n") || strings.Contains(e, "/*") {
+			return false
+		}
+		n += len(e) + 2
+	}
+	return n <= 60
+}
+
+// sub returns the Go source for the given value nested in another one.
+func (r *goSourceRenderer) sub(v reflect.Value, typed, elide bool) string {
+	sub := goSourceRenderer{visiting: r.visiting}
+	sub.render(v, typed, elide)
+	return sub.buf.String()
+}
+
+func (r *goSourceRenderer) renderTime(t time.Time) {
+	loc := "time.UTC"
+	switch {
+	case t.Location() == time.Local:
+		loc = "time.Local"
+	case t.Location() != time.UTC:
+		name, offset := t.Zone()
+		loc = fmt.Sprintf("time.FixedZone(%q, %d)", name, offset)
+	}
+	fmt.Fprintf(&r.buf, "time.Date(%d, %d, %d, %d, %d, %d, %d, %s)",
+		t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// formatFloat formats f as a Go floating point expression.
+func formatFloat(f float64, bits int) string {
+	switch {
+	case math.IsNaN(f):
+		return "math.NaN()"
+	case math.IsInf(f, 1):
+		return "math.Inf(1)"
+	case math.IsInf(f, -1):
+		return "math.Inf(-1)"
+	}
+	s := strconv.FormatFloat(f, 'g', -1, bits)
+	if !strings.ContainsAny(s, ".e") {
+		// Make the literal a floating point constant, so that its default
+		// type is float64.
+		s += ".0"
+	}
+	return s
+}
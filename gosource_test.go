@@ -0,0 +1,153 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package qt_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-quicktest/qt"
+)
+
+type gsInner struct {
+	Name string
+	Tags []string
+}
+
+type gsOuter struct {
+	Inner *gsInner
+	Count int
+}
+
+var goSourceTests = []struct {
+	about string
+	value any
+	want  string
+}{{
+	about: "nil",
+	value: nil,
+	want:  "nil",
+}, {
+	about: "int",
+	value: 42,
+	want:  "42",
+}, {
+	about: "typed int",
+	value: int8(-3),
+	want:  "int8(-3)",
+}, {
+	about: "unsigned",
+	value: uint(7),
+	want:  "uint(7)",
+}, {
+	about: "float",
+	value: 2.0,
+	want:  "2.0",
+}, {
+	about: "float32 NaN",
+	value: float32(math.NaN()),
+	want:  "float32(math.NaN())",
+}, {
+	about: "string",
+	value: "hello \"world\"\n",
+	want:  `"hello \"world\"\n"`,
+}, {
+	about: "short slice",
+	value: []string{"a", "b"},
+	want:  `[]string{"a", "b"}`,
+}, {
+	about: "nil slice",
+	value: []int(nil),
+	want:  "([]int)(nil)",
+}, {
+	about: "map",
+	value: map[string]int{"b": 2, "a": 1},
+	want:  `map[string]int{"a": 1, "b": 2}`,
+}, {
+	about: "interface elements",
+	value: []any{1, "a", int8(2), nil, []int(nil)},
+	want:  `[]interface{}{1, "a", int8(2), nil, ([]int)(nil)}`,
+}, {
+	about: "struct pointer",
+	value: &gsOuter{Inner: &gsInner{Name: "a"}, Count: 2},
+	want: `&qt_test.gsOuter{
+	Inner: &qt_test.gsInner{
+		Name: "a",
+	},
+	Count: 2,
+}`,
+}, {
+	about: "elided pointer elements",
+	value: []*gsInner{{Tags: []string{"x"}}, nil},
+	want: `[]*qt_test.gsInner{
+	{
+		Tags: []string{"x"},
+	},
+	nil,
+}`,
+}, {
+	about: "pointer to basic value",
+	value: func() *int { v := 1; return &v }(),
+	want:  "func() *int { v := 1; return &v }()",
+}, {
+	about: "time",
+	value: time.Date(2012, 3, 28, 0, 0, 0, 0, time.UTC),
+	want:  "time.Date(2012, 3, 28, 0, 0, 0, 0, time.UTC)",
+}, {
+	about: "function",
+	value: func() {},
+	want:  "(func())(nil) /* non-nil func() */",
+}}
+
+func TestGoSourceCyclicValues(t *testing.T) {
+	m := map[string]any{"a": 1}
+	m["self"] = m
+	s := []any{1, nil}
+	s[1] = s
+	tests := []struct {
+		value any
+		want  string
+	}{{
+		value: m,
+		want: `map[string]interface{}{
+	"a":    1,
+	"self": (map[string]interface{})(nil), /* cycle */
+}`,
+	}, {
+		value: s,
+		want: `[]interface{}{
+	1,
+	([]interface{})(nil), /* cycle */
+}`,
+	}}
+	for _, test := range tests {
+		got, err := qt.GoSource(test.value)
+		if err != nil {
+			t.Fatalf("cannot render Go source: %v", err)
+		}
+		if got != test.want {
+			t.Fatalf("Go source:\ngot  %q\nwant %q", got, test.want)
+		}
+	}
+	// The report must not recurse indefinitely either.
+	tt := &testingT{}
+	ok := qt.Check(tt, qt.CmpEquals(m, map[string]any{"a": 2}, qt.ReportGoSource))
+	assertBool(t, ok, false)
+	assertBool(t, strings.Contains(tt.errorString(), "/* cycle */"), true)
+}
+
+func TestGoSource(t *testing.T) {
+	for _, test := range goSourceTests {
+		t.Run(test.about, func(t *testing.T) {
+			got, err := qt.GoSource(test.value)
+			if err != nil {
+				t.Fatalf("cannot render Go source: %v", err)
+			}
+			if got != test.want {
+				t.Fatalf("Go source:\ngot  %q\nwant %q", got, test.want)
+			}
+		})
+	}
+}
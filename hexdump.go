@@ -0,0 +1,140 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package qt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// hexDumpWidth holds the number of bytes shown in each line of a hex dump.
+const hexDumpWidth = 16
+
+// hexDumpContext holds the number of lines shown before and after the line
+// containing the offset of interest when windowing a hex dump.
+const hexDumpContext = 2
+
+// firstDiff returns the offset of the first byte that differs between a
+// and b. If one slice is a prefix of the other, the length of the shorter
+// slice is returned.
+func firstDiff(a, b []byte) int {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
+
+// hexWindow returns the start and end offsets of the hex dump lines shown
+// around the given offset in a slice of the given length.
+func hexWindow(length, offset int) (start, end int) {
+	start = (offset/hexDumpWidth - hexDumpContext) * hexDumpWidth
+	if start < 0 {
+		start = 0
+	}
+	end = (offset/hexDumpWidth + hexDumpContext + 1) * hexDumpWidth
+	if end > length {
+		end = length
+	}
+	return start, end
+}
+
+// hexDump returns a hex dump of b, windowed around the given offset.
+// Each line includes the offset, the hex bytes and their ASCII
+// representation.
+func hexDump(b []byte, offset int) string {
+	if len(b) == 0 {
+		return "<empty>"
+	}
+	start, end := hexWindow(len(b), offset)
+	var buf strings.Builder
+	if start > 0 {
+		fmt.Fprintf(&buf, "... %d bytes omitted\n", start)
+	}
+	for off := start; off < end; off += hexDumpWidth {
+		buf.WriteString(hexDumpLine(b, 0, off))
+		buf.WriteByte('\n')
+	}
+	if end < len(b) {
+		fmt.Fprintf(&buf, "... %d bytes omitted\n", len(b)-end)
+	}
+	return buf.String()
+}
+
+// hexDiff returns a line diff of the hex dumps of want and got, windowed
+// around the given offset. Lines only in want are prefixed with "-" and
+// lines only in got are prefixed with "+".
+func hexDiff(got, want []byte, offset int) string {
+	return hexDiffAt(got, want, 0, offset)
+}
+
+// hexDiffAt is like hexDiff, but got and want hold data starting at the
+// given base offset in a larger stream. The base offset must be a multiple of
+// hexDumpWidth.
+func hexDiffAt(got, want []byte, base, offset int) string {
+	length := len(got)
+	if len(want) > length {
+		length = len(want)
+	}
+	start, end := hexWindow(length, offset)
+	var buf strings.Builder
+	if base+start > 0 {
+		fmt.Fprintf(&buf, "  ... %d bytes omitted\n", base+start)
+	}
+	for off := start; off < end; off += hexDumpWidth {
+		gotLine, wantLine := hexDumpLine(got, base, off), hexDumpLine(want, base, off)
+		if gotLine == wantLine {
+			fmt.Fprintf(&buf, "  %s\n", gotLine)
+			continue
+		}
+		if wantLine != "" {
+			fmt.Fprintf(&buf, "- %s\n", wantLine)
+		}
+		if gotLine != "" {
+			fmt.Fprintf(&buf, "+ %s\n", gotLine)
+		}
+	}
+	if end < length {
+		fmt.Fprintf(&buf, "  ... %d bytes omitted\n", length-end)
+	}
+	return buf.String()
+}
+
+// hexDumpLine returns a single hex dump line for the bytes of b starting at
+// the given offset, or the empty string if the offset is out of range. The
+// offset shown is relative to the given base.
+func hexDumpLine(b []byte, base, off int) string {
+	if off >= len(b) {
+		return ""
+	}
+	line := b[off:]
+	if len(line) > hexDumpWidth {
+		line = line[:hexDumpWidth]
+	}
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%08x ", base+off)
+	for i := 0; i < hexDumpWidth; i++ {
+		if i == hexDumpWidth/2 {
+			buf.WriteByte(' ')
+		}
+		if i < len(line) {
+			fmt.Fprintf(&buf, " %02x", line[i])
+		} else {
+			buf.WriteString("   ")
+		}
+	}
+	buf.WriteString("  |")
+	for _, c := range line {
+		if c < 32 || c > 126 {
+			c = '.'
+		}
+		buf.WriteByte(c)
+	}
+	buf.WriteByte('|')
+	return buf.String()
+}
@@ -0,0 +1,163 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package qt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// maxBodyExcerpt holds the maximum number of body bytes reported when an HTTP
+// response check fails.
+const maxBodyExcerpt = 1024
+
+// StatusCode returns a Checker checking that the provided HTTP response has
+// the given status code. On failure, an excerpt of the response body is
+// reported, as it often explains the unexpected status.
+//
+// The response body is read in order to be reported, and then replaced so
+// that it can still be read by subsequent checks.
+func StatusCode(resp *http.Response, want int) Checker {
+	return &statusCodeChecker{
+		got:  resp,
+		want: want,
+	}
+}
+
+type statusCodeChecker struct {
+	got  *http.Response
+	want int
+}
+
+func (c *statusCodeChecker) Check(note func(key string, value any)) error {
+	if c.got == nil {
+		return BadCheckf("nil response provided")
+	}
+	if c.got.StatusCode == c.want {
+		return nil
+	}
+	if body, err := readBody(c.got); err != nil {
+		note("body error", err)
+	} else if len(body) > 0 {
+		note("body", Unquoted(bodyExcerpt(body)))
+	}
+	return fmt.Errorf("unexpected status: got %d want %d", c.got.StatusCode, c.want)
+}
+
+func (c *statusCodeChecker) Args() []Arg {
+	var status any
+	if c.got != nil {
+		status = c.got.Status
+	}
+	return []Arg{{Name: "got status", Value: status}, {Name: "want status", Value: c.want}}
+}
+
+// HeaderEquals returns a Checker checking that the value of the given header
+// in the provided HTTP response is equal to want. When the header has several
+// values, the first one is compared. On failure, all the response headers
+// are reported.
+func HeaderEquals(resp *http.Response, key, want string) Checker {
+	return &headerEqualsChecker{
+		got:  resp,
+		key:  key,
+		want: want,
+	}
+}
+
+type headerEqualsChecker struct {
+	got  *http.Response
+	key  string
+	want string
+}
+
+func (c *headerEqualsChecker) Check(note func(key string, value any)) error {
+	if c.got == nil {
+		return BadCheckf("nil response provided")
+	}
+	values := c.got.Header.Values(c.key)
+	if len(values) > 0 && values[0] == c.want {
+		return nil
+	}
+	var buf strings.Builder
+	c.got.Header.Write(&buf)
+	note("headers", Unquoted(strings.TrimSuffix(strings.ReplaceAll(buf.String(), "\r\n", "\n"), "\n")))
+	if len(values) == 0 {
+		return fmt.Errorf("header %q not found", c.key)
+	}
+	return errors.New("unexpected header value")
+}
+
+func (c *headerEqualsChecker) Args() []Arg {
+	var got any
+	if c.got != nil {
+		got = c.got.Header.Get(c.key)
+	}
+	return []Arg{{Name: "header", Value: c.key}, {Name: "got", Value: got}, {Name: "want", Value: c.want}}
+}
+
+// BodyMatches returns a Checker checking that the body of the provided HTTP
+// response matches the provided regular expression pattern (see Matches for
+// more details on how the pattern is matched).
+//
+// The response body is read in order to be matched, and then replaced so
+// that it can still be read by subsequent checks.
+func BodyMatches[StringOrRegexp string | *regexp.Regexp](resp *http.Response, pattern StringOrRegexp) Checker {
+	return &bodyMatchesChecker{
+		got:     resp,
+		pattern: pattern,
+		match:   newMatcher(pattern, true),
+	}
+}
+
+type bodyMatchesChecker struct {
+	got     *http.Response
+	pattern any
+	match   matcher
+}
+
+func (c *bodyMatchesChecker) Check(note func(key string, value any)) error {
+	if c.got == nil {
+		return BadCheckf("nil response provided")
+	}
+	body, err := readBody(c.got)
+	if err != nil {
+		return BadCheckf("cannot read response body: %v", err)
+	}
+	err = c.match(string(body), "body does not match pattern", note)
+	if !IsBadCheck(err) {
+		// The body is also reported on success in case the checker is
+		// negated.
+		note("body", Unquoted(bodyExcerpt(body)))
+	}
+	return err
+}
+
+func (c *bodyMatchesChecker) Args() []Arg {
+	return []Arg{{Name: "pattern", Value: c.pattern}}
+}
+
+// readBody reads the body of the given response and replaces it with a
+// reader over the same contents, so that it can be read again.
+func readBody(resp *http.Response) ([]byte, error) {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return body, err
+}
+
+// bodyExcerpt returns the beginning of the given body, truncated to
+// maxBodyExcerpt bytes.
+func bodyExcerpt(body []byte) string {
+	if len(body) <= maxBodyExcerpt {
+		return string(body)
+	}
+	return fmt.Sprintf("%s\n... (%d more bytes)", body[:maxBodyExcerpt], len(body)-maxBodyExcerpt)
+}
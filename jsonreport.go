@@ -0,0 +1,95 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package qt
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// JSONOutput reports whether failures are reported as single-line JSON
+// objects instead of the default human readable format. This is useful when
+// test output is consumed by tools.
+//
+// Each JSON object holds the error, the comments, the notes and checker
+// arguments (as key/value pairs whose values are formatted as in the human
+// readable output) and the stack frames of the failure.
+var JSONOutput = false
+
+// jsonFailure is the JSON representation of a failure report.
+type jsonFailure struct {
+	Error    string      `json:"error,omitempty"`
+	Comments []string    `json:"comments,omitempty"`
+	Notes    []jsonPair  `json:"notes,omitempty"`
+	Args     []jsonPair  `json:"args,omitempty"`
+	Stack    []jsonFrame `json:"stack"`
+}
+
+type jsonPair struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type jsonFrame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// jsonReport generates a single-line JSON failure report for the given error
+// and report parameters.
+func jsonReport(err error, p reportParams) string {
+	var f jsonFailure
+	if err != ErrSilent {
+		f.Error = p.redact(err.Error())
+	}
+	for _, c := range p.comments {
+		if c.note != nil {
+			f.Notes = append(f.Notes, jsonPair{c.note.key, p.redact(formatJSONValue(c.note.value))})
+			continue
+		}
+		if comment := c.String(); comment != "" {
+			f.Comments = append(f.Comments, p.redact(comment))
+		}
+	}
+	for _, n := range p.notes {
+		f.Notes = append(f.Notes, jsonPair{n.key, p.redact(formatJSONValue(n.value))})
+	}
+	if !IsBadCheck(err) && err != ErrSilent {
+		for _, arg := range p.args {
+			f.Args = append(f.Args, jsonPair{arg.Name, p.redact(formatJSONValue(arg.Value))})
+		}
+	}
+	f.Stack = []jsonFrame{}
+	for _, frame := range stackFrames() {
+		f.Stack = append(f.Stack, jsonFrame{
+			Function: frame.Function,
+			File:     frame.File,
+			Line:     frame.Line,
+		})
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(f); err != nil {
+		// This cannot happen as all fields are strings or integers.
+		panic(err)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// formatJSONValue formats the given value for inclusion in a JSON report.
+// Long values are never suppressed.
+func formatJSONValue(value any) string {
+	switch v := value.(type) {
+	case Unquoted:
+		return string(v)
+	case SuppressedIfLong:
+		if u, ok := v.Value.(Unquoted); ok {
+			return string(u)
+		}
+		return Format(v.Value)
+	}
+	return Format(value)
+}
@@ -0,0 +1,58 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package qt
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// goroutineLeakTimeout holds how long NoGoroutineLeak waits for goroutines
+// started by the test to exit.
+var goroutineLeakTimeout = time.Second
+
+// NoGoroutineLeak records the number of running goroutines and checks, at the
+// end of the test (see "Deferred execution" in the package docs), that no more
+// goroutines are running than at call time. Goroutines are given a short time
+// to exit before the check fails.
+//
+// On failure, the stacks of all running goroutines are reported, so that the
+// leaking goroutines can be identified. Since the number of goroutines is
+// global to the process, NoGoroutineLeak should not be used in parallel
+// tests.
+func NoGoroutineLeak(tb testing.TB) {
+	before := runtime.NumGoroutine()
+	tb.Cleanup(func() {
+		after := runtime.NumGoroutine()
+		for deadline := time.Now().Add(goroutineLeakTimeout); after > before && time.Now().Before(deadline); {
+			time.Sleep(10 * time.Millisecond)
+			after = runtime.NumGoroutine()
+		}
+		check(tb, checkParams{
+			fail: tb.Error,
+			checker: NewChecker(nil, func(note func(key string, value any)) error {
+				if after <= before {
+					return nil
+				}
+				note("goroutines before", before)
+				note("goroutines after", after)
+				note("stacks", Unquoted(goroutineStacks()))
+				return errors.New("goroutine leak detected")
+			}),
+		})
+	})
+}
+
+// goroutineStacks returns the stack traces of all running goroutines.
+func goroutineStacks() string {
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return string(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
@@ -2,7 +2,10 @@
 
 package qt
 
-import "testing"
+import (
+	"os"
+	"testing"
+)
 
 // Patch sets a variable to a temporary value for the duration of the test.
 //
@@ -18,3 +21,25 @@ func Patch[T any](tb testing.TB, dest *T, value T) {
 		*dest = old
 	})
 }
+
+// Chdir changes the current working directory to the given directory for the
+// duration of the test, and fails the test if that is not possible.
+//
+// At the end of the test (see "Deferred execution" in the package docs), the
+// original working directory is restored, even if the test panics.
+//
+// Since the working directory is global to the process, Chdir must not be
+// used in parallel tests.
+func Chdir(tb testing.TB, dir string) {
+	tb.Helper()
+	wd, err := os.Getwd()
+	if !Assert(tb, IsNil(err), Commentf("cannot get the current working directory")) {
+		return
+	}
+	if !Assert(tb, IsNil(os.Chdir(dir)), Commentf("cannot change the working directory")) {
+		return
+	}
+	tb.Cleanup(func() {
+		Check(tb, IsNil(os.Chdir(wd)), Commentf("cannot restore the working directory"))
+	})
+}
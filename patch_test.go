@@ -0,0 +1,43 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package qt_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+func TestChdir(t *testing.T) {
+	wd, err := os.Getwd()
+	qt.Assert(t, qt.IsNil(err))
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	qt.Assert(t, qt.IsNil(err))
+
+	tt := &testingT{}
+	qt.Chdir(tt, dir)
+	checkResult(t, tt.fatalString() == "", tt.fatalString(), "")
+	got, err := os.Getwd()
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.Equals(got, dir))
+
+	tt.runCleanups()
+	got, err = os.Getwd()
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.Equals(got, wd))
+}
+
+func TestChdirFailure(t *testing.T) {
+	wd, err := os.Getwd()
+	qt.Assert(t, qt.IsNil(err))
+
+	tt := &testingT{}
+	qt.Chdir(tt, filepath.Join(t.TempDir(), "no-such-dir"))
+	assertPrefix(t, tt.fatalString(), "\nerror:\n  got non-nil value\ncomment:\n  cannot change the working directory\n")
+	tt.runCleanups()
+	got, err := os.Getwd()
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.Equals(got, wd))
+}
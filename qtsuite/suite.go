@@ -46,6 +46,9 @@ The above code could be invoked from a test function like this:
 	func TestHTTPMethods(t *testing.T) {
 		qtsuite.Run(t, &suite{})
 	}
+
+Use RunParallel instead of Run in order to run all the tests in the suite in
+parallel, without calling t.Parallel in each test method.
 */
 package qtsuite
 
@@ -75,6 +78,26 @@ import (
 //
 // this method will be invoked before each test run.
 func Run(t *testing.T, suite any) {
+	run(t, suite, false)
+}
+
+// RunParallel is like Run, except that each test is run in parallel with
+// the others, as if t.Parallel was called at the start of each subtest.
+//
+// The Init method, if present, is invoked in each subtest after t.Parallel
+// is called, so it runs concurrently with other tests too, on the copy of
+// the suite used by that test only when suite is a pointer. Cleanup
+// functions registered by Init or by the test itself run when that test
+// completes.
+//
+// Note that, as for all parallel subtests, the tests are not complete when
+// RunParallel returns, but only once the enclosing test function has
+// returned, before the cleanup functions registered on t are run.
+func RunParallel(t *testing.T, suite any) {
+	run(t, suite, true)
+}
+
+func run(t *testing.T, suite any, parallel bool) {
 	sv := reflect.ValueOf(suite)
 	st := sv.Type()
 	init, hasInit := st.MethodByName("Init")
@@ -90,6 +113,9 @@ func Run(t *testing.T, suite any) {
 			if !isValidMethod(m) {
 				t.Fatalf("wrong signature for %s, must be %s(*testing.T)", m.Name, m.Name)
 			}
+			if parallel {
+				t.Parallel()
+			}
 
 			sv := sv
 			if st.Kind() == reflect.Pointer {
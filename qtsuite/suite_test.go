@@ -3,6 +3,8 @@
 package qtsuite_test
 
 import (
+	"sort"
+	"sync"
 	"testing"
 
 	"github.com/go-quicktest/qt"
@@ -41,6 +43,40 @@ func TestRunSuitePtr(t *testing.T) {
 	}))
 }
 
+func TestRunParallelSuite(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	record := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, name)
+	}
+	t.Run("suite", func(t *testing.T) {
+		qtsuite.RunParallel(t, &parallelSuite{record: record})
+		// Parallel subtests are only run after this function returns.
+		record("RunParallel")
+	})
+	qt.Assert(t, qt.Equals(calls[0], "RunParallel"))
+	sort.Strings(calls)
+	qt.Assert(t, qt.DeepEquals(calls, []string{"Init", "Init", "RunParallel", "Test1", "Test2"}))
+}
+
+type parallelSuite struct {
+	record func(name string)
+}
+
+func (s *parallelSuite) Init(*testing.T) {
+	s.record("Init")
+}
+
+func (s *parallelSuite) Test1(*testing.T) {
+	s.record("Test1")
+}
+
+func (s *parallelSuite) Test2(*testing.T) {
+	s.record("Test2")
+}
+
 type testSuite struct {
 	init  int
 	calls *[]call
@@ -3,6 +3,8 @@
 package qt
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 )
 
@@ -19,6 +21,11 @@ func Assert(t testing.TB, checker Checker, comments ...Comment) bool {
 
 // Check checks that the provided argument passes the given check and calls
 // tb.Error otherwise, including any Comment arguments in the failure.
+//
+// The failure report, stack included, is identical to the one Assert would
+// produce for the same call: the only difference is that the test is not
+// aborted, so Check can be used in place of Assert when subsequent checks
+// are still meaningful.
 func Check(t testing.TB, checker Checker, comments ...Comment) bool {
 	t.Helper()
 	return check(t, checkParams{
@@ -28,11 +35,126 @@ func Check(t testing.TB, checker Checker, comments ...Comment) bool {
 	})
 }
 
-func check(t testing.TB, p checkParams) bool {
+// CheckFast is like Check, without comments, but it is optimized for
+// checks run in tight loops, for instance in benchmarks and fuzz targets:
+// successful checks only run the checker, without registering the caller as
+// a test helper or retrieving the checker arguments. On failure, the report
+// is the same as the one Check would produce.
+func CheckFast(t testing.TB, checker Checker) bool {
+	if checker == nil {
+		t.Helper()
+		return check(t, checkParams{
+			fail:    t.Error,
+			checker: checker,
+		})
+	}
+	var rp reportParams
+	err := checker.Check(func(key string, value any) {
+		rp.notes = append(rp.notes, note{
+			key:   key,
+			value: value,
+		})
+	})
+	if err == nil {
+		return true
+	}
+	t.Helper()
+	rp.args = checker.Args()
+	reportFast(t, err, rp)
+	return false
+}
+
+// reportFast reports a failure found by CheckFast. It is a separate function
+// so that the stack frames found by report are the same as with check.
+func reportFast(t testing.TB, err error, rp reportParams) {
+	t.Helper()
+	t.Error(report(err, rp))
+}
+
+// AssertError checks that the provided error is nil, if want is nil, or that
+// it is or wraps want otherwise, as with errors.Is. It calls tb.Fatal on
+// failure, including any Comment arguments in the failure.
+//
+// It is equivalent to calling Assert with IsNil(got) or ErrorIs(got, want).
+func AssertError(t testing.TB, got, want error, comments ...Comment) bool {
+	t.Helper()
+	var checker Checker
+	if want == nil {
+		checker = IsNil(got)
+	} else {
+		checker = ErrorIs(got, want)
+	}
+	return check(t, checkParams{
+		fail:     t.Fatal,
+		checker:  checker,
+		comments: comments,
+	})
+}
+
+// Run checks that the provided argument passes the given check and returns an
+// error otherwise, including any Comment arguments in the failure. The error
+// message is the same failure report that Assert and Check would print.
+//
+// Run does not require a testing.TB, so it can be used to run checkers outside
+// of tests or to route failures in a custom way.
+func Run(checker Checker, comments ...Comment) error {
+	var err error
+	check(nil, checkParams{
+		fail: func(args ...any) {
+			err = errors.New(fmt.Sprint(args...))
+		},
+		checker:  checker,
+		comments: comments,
+	})
+	return err
+}
+
+// Must checks that the provided argument passes the given check and panics
+// otherwise, including any Comment arguments in the failure. The panic value
+// is the same failure report that Assert and Check would print.
+//
+// Must is useful where no testing.TB is available, for instance in TestMain,
+// in benchmark setup or in examples.
+func Must(checker Checker, comments ...Comment) {
+	check(nil, checkParams{
+		fail: func(args ...any) {
+			panic(fmt.Sprint(args...))
+		},
+		checker:  checker,
+		comments: comments,
+	})
+}
+
+// Failf fails the test by calling tb.Fatal with the error formatted according
+// to the given format specifier and args. The failure is reported in the
+// same way as a failed assertion, including the stack. This is useful when a
+// problem has already been detected by other means, for instance in the
+// default case of a switch statement.
+func Failf(t testing.TB, format string, args ...any) {
 	t.Helper()
+	check(t, checkParams{
+		fail: t.Fatal,
+		checker: failChecker{
+			err: fmt.Errorf(format, args...),
+		},
+	})
+}
+
+// check runs the check described by p. The given t may be nil when the check
+// is not run as part of a test.
+func check(t testing.TB, p checkParams) bool {
+	if t != nil {
+		t.Helper()
+	}
 	rp := reportParams{
 		comments: p.comments,
 	}
+	for _, c := range p.comments {
+		if c.verbose {
+			rp.verbose = true
+		}
+		rp.redacted = append(rp.redacted, c.redacted...)
+	}
 
 	// Allow checkers to annotate messages.
 	note := func(key string, value any) {
@@ -47,10 +169,12 @@ func check(t testing.TB, p checkParams) bool {
 		p.fail(report(BadCheckf("nil checker provided"), rp))
 		return false
 	}
-	rp.args = p.checker.Args()
 
-	// Run the check.
+	// Run the check. The checker arguments are only retrieved on failure, so
+	// that successful checks are as cheap as possible, for instance when
+	// used in benchmarks.
 	if err := p.checker.Check(note); err != nil {
+		rp.args = p.checker.Args()
 		p.fail(report(err, rp))
 		return false
 	}
@@ -62,3 +186,16 @@ type checkParams struct {
 	checker  Checker
 	comments []Comment
 }
+
+// failChecker is a Checker that always fails with the stored error.
+type failChecker struct {
+	err error
+}
+
+func (c failChecker) Check(note func(key string, value any)) error {
+	return c.err
+}
+
+func (c failChecker) Args() []Arg {
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-quicktest/qt"
 )
@@ -80,6 +81,113 @@ comment:
 got:
   "something"
 `,
+}, {
+	about:   "failure with lazy comment",
+	checker: qt.IsNil(any(42)),
+	comments: []qt.Comment{qt.CommentLazy(func() string {
+		return "computed on failure"
+	})},
+	expectedFailure: `
+error:
+  got non-nil value
+comment:
+  computed on failure
+got:
+  int(42)
+`,
+}, {
+	about:   "failure with notes",
+	checker: qt.Equals(42, 47),
+	comments: []qt.Comment{
+		qt.Commentf("bad wolf"),
+		qt.Note("input", []int{1, 2}),
+		qt.Note("expected", 47),
+	},
+	expectedFailure: `
+error:
+  values are not equal
+comment:
+  bad wolf
+input:
+  []int{1, 2}
+expected:
+  int(47)
+got:
+  int(42)
+want:
+  <same as "expected">
+`,
+}, {
+	about: "failure with verbose comment",
+	checker: &testingChecker{
+		addNotes: func(note func(key string, value any)) {
+			note("long", qt.SuppressedIfLong{Value: []any{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}})
+		},
+		err: errors.New("bad wolf"),
+	},
+	comments: []qt.Comment{qt.Verbose()},
+	expectedFailure: `
+error:
+  bad wolf
+long:
+  []interface {}{
+      int(1),
+      int(2),
+      int(3),
+      int(4),
+      int(5),
+      int(6),
+      int(7),
+      int(8),
+      int(9),
+      int(10),
+      int(11),
+  }
+`,
+}, {
+	about:    "failure with redacted values",
+	checker:  qt.Equals("s3cr3t", "t0k3n"),
+	comments: []qt.Comment{qt.Redact("s3cr3t", "t0k3n")},
+	expectedFailure: `
+error:
+  values are not equal
+got:
+  <redacted>
+want:
+  <redacted>
+`,
+}, {
+	about: "failure with redacted values in notes and comments",
+	checker: &testingChecker{
+		args: []qt.Arg{{
+			Name:  "got",
+			Value: map[string]string{"token": "s3cr3t"},
+		}, {
+			Name:  "want",
+			Value: 42,
+		}},
+		addNotes: func(note func(key string, value any)) {
+			note("diff", qt.Unquoted("- \"t0k3n\\n\"\n+ \"s3cr3t\\n\""))
+		},
+		err: errors.New("bad token s3cr3t"),
+	},
+	comments: []qt.Comment{
+		qt.Commentf("using %s", "s3cr3t"),
+		qt.Redact([]byte("s3cr3t"), "t0k3n\n", 42),
+	},
+	expectedFailure: `
+error:
+  bad token <redacted>
+comment:
+  using <redacted>
+diff:
+  - "<redacted>"
+  + "<redacted>\n"
+got:
+  map[string]string{"token":"<redacted>"}
+want:
+  <redacted>
+`,
 }, {
 	about:    "failure with empty comment",
 	checker:  qt.IsNil(any(47)),
@@ -262,7 +370,207 @@ func TestCAssertCheck(t *testing.T) {
 				t.Fatalf("no fatal messages expected, but got %q", tt.fatalString())
 			}
 		})
+		if len(test.comments) == 0 {
+			t.Run("CheckFast: "+test.about, func(t *testing.T) {
+				tt := &testingT{}
+				ok := qt.CheckFast(tt, test.checker)
+				checkResult(t, ok, tt.errorString(), test.expectedFailure)
+				if tt.fatalString() != "" {
+					t.Fatalf("no fatal messages expected, but got %q", tt.fatalString())
+				}
+			})
+		}
+		t.Run("Run: "+test.about, func(t *testing.T) {
+			err := qt.Run(test.checker, test.comments...)
+			var got string
+			if err != nil {
+				got = err.Error()
+			}
+			checkResult(t, err == nil, got, test.expectedFailure)
+		})
+		t.Run("Must: "+test.about, func(t *testing.T) {
+			var got string
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						got = r.(string)
+					}
+				}()
+				qt.Must(test.checker, test.comments...)
+			}()
+			checkResult(t, got == "", got, test.expectedFailure)
+		})
+	}
+}
 
+func TestAssertCheckSameReport(t *testing.T) {
+	// Both functions are called from the same line, so that the stacks are
+	// also expected to be the same.
+	report := func(f func(testing.TB, qt.Checker, ...qt.Comment) bool) *testingT {
+		tt := &testingT{}
+		f(tt, qt.DeepEquals([]int{1, 2}, []int{1, 3}), qt.Commentf("bad wolf"))
+		return tt
+	}
+	assertTT, checkTT := report(qt.Assert), report(qt.Check)
+	assertBool(t, assertTT.errorString() == "", true)
+	assertBool(t, checkTT.fatalString() == "", true)
+	assertPrefix(t, assertTT.fatalString(), "\ncomment:\n  bad wolf\nerror:\n  values are not deep equal\n")
+	if got, want := checkTT.errorString(), assertTT.fatalString(); got != want {
+		t.Fatalf("Check report differs from Assert report:\ngot  %q\nwant %q", got, want)
+	}
+	assertBool(t, strings.Contains(checkTT.errorString(), "\nstack:\n"), true)
+}
+
+func TestCheckFastSameReport(t *testing.T) {
+	checker := qt.DeepEquals([]int{1, 2}, []int{1, 3})
+	checkTT, fastTT := &testingT{}, &testingT{}
+	qt.Check(checkTT, checker)
+	qt.CheckFast(fastTT, checker)
+	want, _, _ := strings.Cut(checkTT.errorString(), "stack:\n")
+	assertPrefix(t, fastTT.errorString(), want+"stack:\n")
+	assertBool(t, strings.Contains(fastTT.errorString(), "\n    qt.CheckFast(fastTT, checker)\n"), true)
+}
+
+func TestErrorDoesNotContainRedacted(t *testing.T) {
+	const secret = "s3cr3t"
+	tt := &testingT{}
+	ok := qt.Check(tt, qt.ErrorDoesNotContain(errors.New("invalid password "+secret), secret), qt.Redact(secret))
+	assertBool(t, ok, false)
+	assertPrefix(t, tt.errorString(), `
+error:
+  error message contains substring
+found at offset:
+  int(17)
+got error:
+  e"invalid password <redacted>"
+substr:
+  <redacted>
+`)
+	assertBool(t, strings.Contains(tt.errorString(), secret), false)
+}
+
+var assertErrorTests = []struct {
+	about           string
+	got             error
+	want            error
+	expectedFailure string
+}{{
+	about: "nil error",
+}, {
+	about: "unexpected error",
+	got:   errors.New("bad wolf"),
+	expectedFailure: `
+error:
+  got non-nil value
+got:
+  e"bad wolf"
+`,
+}, {
+	about: "matching error",
+	got:   fmt.Errorf("wrapped: %w", errSentinel),
+	want:  errSentinel,
+}, {
+	about: "missing error",
+	want:  errSentinel,
+	expectedFailure: `
+error:
+  got nil error but want non-nil
+got:
+  nil
+want:
+  e"test error"
+`,
+}, {
+	about: "mismatched error",
+	got:   errors.New("bad wolf"),
+	want:  errSentinel,
+	expectedFailure: `
+error:
+  wanted error is not found in error chain
+got:
+  e"bad wolf"
+want:
+  e"test error"
+`,
+}}
+
+var errSentinel = errors.New("test error")
+
+func TestAssertError(t *testing.T) {
+	for _, test := range assertErrorTests {
+		t.Run(test.about, func(t *testing.T) {
+			tt := &testingT{}
+			ok := qt.AssertError(tt, test.got, test.want)
+			checkResult(t, ok, tt.fatalString(), test.expectedFailure)
+		})
+	}
+}
+
+func TestCounter(t *testing.T) {
+	tt := &testingT{}
+	c := qt.NewCounter(tt)
+	assertBool(t, c.Check(qt.Equals(1, 1)), true)
+	assertBool(t, c.Assert(qt.IsTrue(true)), true)
+	assertBool(t, c.Check(qt.Equals(1, 2)), false)
+	if got := c.Count(); got != 3 {
+		t.Fatalf("count: got %d, want 3", got)
+	}
+	tt.errorBuf.Reset()
+	assertBool(t, c.Expect(3), true)
+	checkResult(t, c.Expect(4), tt.errorString(), `
+error:
+  values are not equal
+comment:
+  unexpected number of checks run
+got:
+  int(3)
+want:
+  int(4)
+`)
+}
+
+func TestNoGoroutineLeak(t *testing.T) {
+	tt := &testingT{}
+	qt.NoGoroutineLeak(tt)
+	done := make(chan struct{})
+	go func() {
+		<-done
+	}()
+	close(done)
+	tt.runCleanups()
+	checkResult(t, tt.errorString() == "", tt.errorString(), "")
+}
+
+func TestNoGoroutineLeakFailure(t *testing.T) {
+	qt.Patch(t, qt.GoroutineLeakTimeout, 50*time.Millisecond)
+	tt := &testingT{}
+	qt.NoGoroutineLeak(tt)
+	done := make(chan struct{})
+	defer close(done)
+	go leakingGoroutine(done)
+	tt.runCleanups()
+	assertPrefix(t, tt.errorString(), "\nerror:\n  goroutine leak detected\ngoroutines before:\n")
+	if !strings.Contains(tt.errorString(), "qt_test.leakingGoroutine") {
+		t.Fatalf("leaking goroutine not found in stacks:\n%s", tt.errorString())
+	}
+}
+
+func leakingGoroutine(done chan struct{}) {
+	<-done
+}
+
+func TestArgsOnlyCalledOnFailure(t *testing.T) {
+	checker := &argsCountingChecker{}
+	tt := &testingT{}
+	qt.Check(tt, checker)
+	if checker.argsCalls != 0 {
+		t.Fatalf("Args called %d times on success", checker.argsCalls)
+	}
+
+	checker.err = errors.New("bad wolf")
+	qt.Check(tt, checker)
+	if checker.argsCalls != 1 {
+		t.Fatalf("Args called %d times on failure, want 1", checker.argsCalls)
 	}
 }
 
@@ -306,6 +614,7 @@ type testingT struct {
 
 	helperCalls int
 	parallel    bool
+	cleanups    []func()
 }
 
 // Error overrides testing.TB.Error so that messages are collected.
@@ -324,6 +633,21 @@ func (t *testingT) Parallel() {
 	t.parallel = true
 }
 
+// Cleanup overrides testing.TB.Cleanup in order to record the given
+// function, so that it can be called by runCleanups.
+func (t *testingT) Cleanup(f func()) {
+	t.cleanups = append(t.cleanups, f)
+}
+
+// runCleanups calls the recorded cleanup functions in last added, first
+// called order.
+func (t *testingT) runCleanups() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+	t.cleanups = nil
+}
+
 // Helper overrides testing.TB.Helper in order to count calls.
 func (t *testingT) Helper() {
 	t.helperCalls += 1
@@ -394,3 +718,41 @@ func (c *testingChecker) Check(note func(key string, value any)) error {
 func (c *testingChecker) Args() []qt.Arg {
 	return c.args
 }
+
+func BenchmarkCheckSuccess(b *testing.B) {
+	got := []int{1, 2, 3, 4, 5}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		qt.Check(b, qt.SliceAll(got, func(elem int) qt.Checker {
+			return qt.Not(qt.Equals(elem, 0))
+		}))
+	}
+}
+
+func BenchmarkCheckFastSuccess(b *testing.B) {
+	got := []int{1, 2, 3, 4, 5}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		qt.CheckFast(b, qt.SliceAll(got, func(elem int) qt.Checker {
+			return qt.Not(qt.Equals(elem, 0))
+		}))
+	}
+}
+
+// argsCountingChecker is a quicktest.Checker that records how many times its
+// Args method is called. When the check is run the stored error is returned.
+type argsCountingChecker struct {
+	err       error
+	argsCalls int
+}
+
+// Check implements quicktest.Checker by returning the stored error.
+func (c *argsCountingChecker) Check(note func(key string, value any)) error {
+	return c.err
+}
+
+// Args implements quicktest.Checker by recording the call.
+func (c *argsCountingChecker) Args() []qt.Arg {
+	c.argsCalls++
+	return []qt.Arg{{Name: "got", Value: 42}}
+}
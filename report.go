@@ -12,6 +12,7 @@ import (
 	"io"
 	"reflect"
 	"runtime"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -24,6 +25,40 @@ type reportParams struct {
 	comments []Comment
 	// notes holds notes added while doing the check.
 	notes []note
+	// verbose reports whether long values must be output even if verbose
+	// testing is off.
+	verbose bool
+	// redacted holds values that must be masked in the output.
+	redacted []any
+}
+
+// redactedValue is shown in place of redacted values.
+const redactedValue = "<redacted>"
+
+// redact returns the given formatted output with the redacted values masked.
+func (p reportParams) redact(s string) string {
+	for _, r := range p.redacted {
+		if s == Format(r) {
+			return redactedValue
+		}
+		var raw string
+		switch r := r.(type) {
+		case string:
+			raw = r
+		case []byte:
+			raw = string(r)
+		}
+		if raw == "" {
+			continue
+		}
+		s = strings.ReplaceAll(s, raw, redactedValue)
+		// Also mask the value when it is escaped, for instance in a quoted
+		// multi-line string.
+		if quoted := strconv.Quote(raw); quoted[1:len(quoted)-1] != raw {
+			s = strings.ReplaceAll(s, quoted[1:len(quoted)-1], redactedValue)
+		}
+	}
+	return s
 }
 
 // Unquoted indicates that the string must not be pretty printed in the failure
@@ -47,6 +82,9 @@ const longValueLines = 10
 // in the output the checker arguments, comment and notes included in the
 // provided report parameters.
 func report(err error, p reportParams) string {
+	if JSONOutput {
+		return jsonReport(err, p)
+	}
 	var buf bytes.Buffer
 	buf.WriteByte('\n')
 	writeError(&buf, err, p)
@@ -69,8 +107,12 @@ func writeError(w io.Writer, err error, p reportParams) {
 			v = string(u)
 		} else if s, ok := value.(SuppressedIfLong); ok {
 			// Check whether the output is too long and must be suppressed.
-			v = Format(s.Value)
-			if !testingVerbose() {
+			if u, ok := s.Value.(Unquoted); ok {
+				v = string(u)
+			} else {
+				v = Format(s.Value)
+			}
+			if !p.verbose && !testingVerbose() {
 				if n := strings.Count(v, "\n"); n > longValueLines {
 					fmt.Fprint(w, prefixf(prefix, "<suppressed due to length (%d lines), use -v for full output>", n))
 					return
@@ -94,7 +136,7 @@ func writeError(w io.Writer, err error, p reportParams) {
 		}
 
 		values[v] = key
-		fmt.Fprint(w, prefixf(prefix, "%s", v))
+		fmt.Fprint(w, prefixf(prefix, "%s", p.redact(v)))
 	}
 
 	// Write the checker error.
@@ -104,6 +146,10 @@ func writeError(w io.Writer, err error, p reportParams) {
 
 	// Write comments if provided.
 	for _, c := range p.comments {
+		if c.note != nil {
+			printPair(c.note.key, c.note.value)
+			continue
+		}
 		if comment := c.String(); comment != "" {
 			printPair("comment", Unquoted(comment))
 		}
@@ -124,6 +170,13 @@ func writeError(w io.Writer, err error, p reportParams) {
 	}
 }
 
+// StackDepth holds the maximum number of call frames inspected, starting from
+// the failing check, when building the stack section of failure reports.
+// Frames belonging to the testing package and to the quicktest exported API
+// are inspected but never included, so that fewer frames may be reported. A
+// value of zero or less means that there is no limit.
+var StackDepth = 8
+
 // testingVerbose is defined as a variable for testing.
 var testingVerbose = func() bool {
 	return testing.Verbose()
@@ -133,7 +186,6 @@ var testingVerbose = func() bool {
 // provided writer.
 func writeStack(w io.Writer) {
 	fmt.Fprintln(w, "stack:")
-	pc := make([]uintptr, 8)
 	sg := &stmtGetter{
 		fset:  token.NewFileSet(),
 		files: make(map[string]*ast.File, 8),
@@ -142,16 +194,59 @@ func writeStack(w io.Writer) {
 			Tabwidth: 4,
 		},
 	}
-	runtime.Callers(5, pc)
+	for _, frame := range stackFrames() {
+		fmt.Fprint(w, prefixf(prefix, "%s:%d", frame.File, frame.Line))
+		if strings.HasSuffix(frame.File, ".go") {
+			stmt, err := sg.Get(frame.File, frame.Line)
+			if err != nil {
+				fmt.Fprint(w, prefixf(prefix+prefix, "<%s>", err))
+			} else {
+				fmt.Fprint(w, prefixf(prefix+prefix, "%s", stmt))
+			}
+		}
+	}
+}
+
+// stackFrames returns the frames of the current failure that must be included
+// in the report, from the most recent call. It must be called by functions
+// called directly by report.
+func stackFrames() []runtime.Frame {
+	var pc []uintptr
+	if StackDepth > 0 {
+		pc = make([]uintptr, StackDepth)
+		pc = pc[:runtime.Callers(6, pc)]
+	} else {
+		pc = make([]uintptr, 16)
+		for {
+			n := runtime.Callers(6, pc)
+			if n < len(pc) {
+				pc = pc[:n]
+				break
+			}
+			pc = make([]uintptr, 2*len(pc))
+		}
+	}
 	frames := runtime.CallersFrames(pc)
 	thisPackage := reflect.TypeOf(Unquoted("")).PkgPath() + "."
+	var result []runtime.Frame
 	for {
 		frame, more := frames.Next()
+		if frame.PC == 0 {
+			// All the collected frames have been skipped.
+			break
+		}
 		if strings.HasPrefix(frame.Function, "testing.") {
 			// Stop before getting back to stdlib test runner calls.
 			break
 		}
 		if fname := strings.TrimPrefix(frame.Function, thisPackage); fname != frame.Function {
+			if strings.HasPrefix(fname, "(") {
+				// Use the method name for methods with pointer receivers,
+				// for instance "(*Counter).Assert".
+				if i := strings.Index(fname, ")."); i != -1 {
+					fname = fname[i+2:]
+				}
+			}
 			if ast.IsExported(fname) {
 				// Continue without printing frames for quicktest exported API.
 				continue
@@ -160,20 +255,13 @@ func writeStack(w io.Writer) {
 			// This is useful for instance when using qtsuite.
 			break
 		}
-		fmt.Fprint(w, prefixf(prefix, "%s:%d", frame.File, frame.Line))
-		if strings.HasSuffix(frame.File, ".go") {
-			stmt, err := sg.Get(frame.File, frame.Line)
-			if err != nil {
-				fmt.Fprint(w, prefixf(prefix+prefix, "<%s>", err))
-			} else {
-				fmt.Fprint(w, prefixf(prefix+prefix, "%s", stmt))
-			}
-		}
+		result = append(result, frame)
 		if !more {
 			// There are no more callers.
 			break
 		}
 	}
+	return result
 }
 
 type stmtGetter struct {
@@ -159,6 +159,69 @@ stack:
 	assertReport(t, tt, want)
 }
 
+func TestFailfReportOutput(t *testing.T) {
+	tt := &testingT{}
+	qt.Failf(tt, "unexpected value %d", 42)
+	want := `
+error:
+  unexpected value 42
+stack:
+  $file:164
+    qt.Failf(tt, "unexpected value %d", 42)
+`
+	assertReport(t, tt, want)
+}
+
+func TestStackDepthReportOutput(t *testing.T) {
+	defer func(depth int) {
+		qt.StackDepth = depth
+	}(qt.StackDepth)
+	qt.StackDepth = 2
+	tt := &testingT{}
+	f1(tt)
+	want := `
+error:
+  got non-nil value
+got:
+  []int{}
+stack:
+  $file:37
+    qt.Assert(t, qt.IsNil([]int{}))
+  $file:33
+    f2(t)
+`
+	assertReport(t, tt, want)
+}
+
+func TestJSONReportOutput(t *testing.T) {
+	defer func(v bool) {
+		qt.JSONOutput = v
+	}(qt.JSONOutput)
+	qt.JSONOutput = true
+	tt := &testingT{}
+	qt.Assert(tt, qt.Equals(42, 47), qt.Commentf("a comment"), qt.Note("input", "<value>"))
+	want := `{"error":"values are not equal","comments":["a comment"],"notes":[{"key":"input","value":"\"<value>\""}],"args":[{"key":"got","value":"int(42)"},{"key":"want","value":"int(47)"}],"stack":[{"function":"github.com/go-quicktest/qt_test.TestJSONReportOutput","file":"$file","line":202}]}`
+	assertReport(t, tt, want)
+}
+
+func TestCounterReportOutput(t *testing.T) {
+	tt := &testingT{}
+	c := qt.NewCounter(tt)
+	c.Assert(qt.Equals(42, 47))
+	want := `
+error:
+  values are not equal
+got:
+  int(42)
+want:
+  int(47)
+stack:
+  $file:210
+    c.Assert(qt.Equals(42, 47))
+`
+	assertReport(t, tt, want)
+}
+
 func assertReport(t *testing.T, tt *testingT, want string) {
 	t.Helper()
 	got := strings.Replace(tt.fatalString(), "\t", "        ", -1)
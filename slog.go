@@ -0,0 +1,170 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+//go:build go1.21
+
+package qt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// CaptureSlog installs a slog handler capturing all records logged with the
+// default logger, at every level, for the duration of the test.
+//
+// At the end of the test (see "Deferred execution" in the package docs), the
+// previous default logger is restored. Since the default logger is global to
+// the process, CaptureSlog must not be used in parallel tests.
+//
+// The returned capture can be checked with LoggedAt and LoggedMessage, and
+// its records can be retrieved for custom assertions.
+func CaptureSlog(tb testing.TB) *SlogCapture {
+	c := &SlogCapture{}
+	prev := slog.Default()
+	slog.SetDefault(slog.New(&slogHandler{capture: c}))
+	tb.Cleanup(func() {
+		slog.SetDefault(prev)
+	})
+	return c
+}
+
+// SlogCapture holds the records captured by CaptureSlog.
+type SlogCapture struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+// Records returns the records captured so far, in the order they were logged.
+// Attributes added with Logger.With and groups are included in each record.
+func (c *SlogCapture) Records() []slog.Record {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	records := make([]slog.Record, len(c.records))
+	copy(records, c.records)
+	return records
+}
+
+// String returns the captured records, one per line, formatted as the level,
+// the message and the attributes.
+func (c *SlogCapture) String() string {
+	var lines []string
+	for _, r := range c.Records() {
+		line := r.Level.String() + " " + r.Message
+		r.Attrs(func(a slog.Attr) bool {
+			line += " " + a.String()
+			return true
+		})
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// LoggedAt returns a Checker checking that at least one record has been
+// captured at the given level.
+func LoggedAt(c *SlogCapture, level slog.Level) Checker {
+	return &slogChecker{
+		capture: c,
+		arg:     Arg{Name: "level", Value: Unquoted(level.String())},
+		match: func(r slog.Record) bool {
+			return r.Level == level
+		},
+		err: fmt.Sprintf("no record logged at level %s", level),
+	}
+}
+
+// LoggedMessage returns a Checker checking that at least one captured record
+// has a message containing the given substring.
+func LoggedMessage(c *SlogCapture, substr string) Checker {
+	return &slogChecker{
+		capture: c,
+		arg:     Arg{Name: "substr", Value: substr},
+		match: func(r slog.Record) bool {
+			return strings.Contains(r.Message, substr)
+		},
+		err: "no record message contains substring",
+	}
+}
+
+type slogChecker struct {
+	capture *SlogCapture
+	arg     Arg
+	match   func(slog.Record) bool
+	err     string
+}
+
+func (c *slogChecker) Check(note func(key string, value any)) error {
+	if c.capture == nil {
+		return BadCheckf("nil capture provided")
+	}
+	for _, r := range c.capture.Records() {
+		if c.match(r) {
+			return nil
+		}
+	}
+	return errors.New(c.err)
+}
+
+func (c *slogChecker) Args() []Arg {
+	records := Unquoted("no records captured")
+	if c.capture != nil && len(c.capture.Records()) != 0 {
+		records = Unquoted(c.capture.String())
+	}
+	return []Arg{{Name: "records", Value: records}, c.arg}
+}
+
+// slogHandler is a slog.Handler storing records in a SlogCapture.
+type slogHandler struct {
+	capture *SlogCapture
+	attrs   []slog.Attr
+	groups  []string
+}
+
+func (h *slogHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *slogHandler) Handle(_ context.Context, r slog.Record) error {
+	rec := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	rec.AddAttrs(h.attrs...)
+	var attrs []slog.Attr
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	rec.AddAttrs(h.group(attrs)...)
+	h.capture.mu.Lock()
+	defer h.capture.mu.Unlock()
+	h.capture.records = append(h.capture.records, rec)
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h1 := *h
+	h1.attrs = append(h.attrs[:len(h.attrs):len(h.attrs)], h.group(attrs)...)
+	return &h1
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h1 := *h
+	h1.groups = append(h.groups[:len(h.groups):len(h.groups)], name)
+	return &h1
+}
+
+// group returns the given attributes nested in the current groups.
+func (h *slogHandler) group(attrs []slog.Attr) []slog.Attr {
+	if len(attrs) == 0 {
+		return nil
+	}
+	for i := len(h.groups) - 1; i >= 0; i-- {
+		attrs = []slog.Attr{{Key: h.groups[i], Value: slog.GroupValue(attrs...)}}
+	}
+	return attrs
+}
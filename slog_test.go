@@ -0,0 +1,65 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+//go:build go1.21
+
+package qt_test
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+func TestCaptureSlog(t *testing.T) {
+	prev := slog.Default()
+	tt := &testingT{}
+	c := qt.CaptureSlog(tt)
+
+	logger := slog.With("request", 42).WithGroup("user")
+	logger.Info("user logged in", "name", "bob")
+	slog.Debug("cache miss")
+	slog.Warn("slow query", "duration", "2s")
+
+	records := c.Records()
+	assertBool(t, len(records) == 3, true)
+	assertBool(t, records[0].Message == "user logged in", true)
+	assertBool(t, records[1].Level == slog.LevelDebug, true)
+
+	ok := qt.Check(tt, qt.LoggedAt(c, slog.LevelWarn))
+	checkResult(t, ok, tt.errorString(), "")
+
+	ok = qt.Check(tt, qt.LoggedMessage(c, "logged in"))
+	checkResult(t, ok, tt.errorString(), "")
+
+	ok = qt.Check(tt, qt.LoggedAt(c, slog.LevelError))
+	checkResult(t, ok, tt.errorString(), `
+error:
+  no record logged at level ERROR
+records:
+  INFO user logged in request=42 user=[name=bob]
+  DEBUG cache miss
+  WARN slow query duration=2s
+level:
+  ERROR
+`)
+
+	tt.runCleanups()
+	assertBool(t, slog.Default() == prev, true)
+}
+
+func TestLoggedMessageNoRecords(t *testing.T) {
+	tt := &testingT{}
+	c := qt.CaptureSlog(tt)
+	defer tt.runCleanups()
+
+	ok := qt.Check(tt, qt.LoggedMessage(c, "started"))
+	checkResult(t, ok, tt.errorString(), `
+error:
+  no record message contains substring
+records:
+  no records captured
+substr:
+  "started"
+`)
+}